@@ -2,12 +2,13 @@ package main
 
 import (
 	_ "embed"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/NeerajCodz/dgf/provider"
 	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
 	"github.com/spf13/pflag"
 )
 
@@ -18,26 +19,54 @@ var formatsData []byte
 func ParseArgs() types.Args {
 	var args types.Args
 	var format string // Temporary variable for --format flag
+	var minSize, maxSize, maxTotal string
 
 	// Define custom usage message
 	pflag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Usage:
   ./dgf [ <URL> | -s <site> -u <username> -r <repo> ] [options]
+  ./dgf -s <site> <URL> [options]    (self-hosted instances)
+  ./dgf update [<dir>] [options]     (refresh a previous download from its .dgf.lock)
+  ./dgf cat <URL> [options]          (write a single file to stdout)
+  ./dgf formats [--config <file>]    (list the format categories)
 
 Options:
   --site, -s <site>           Platform ID (e.g., github, gitlab, huggingface)
   --username, -u <username>   Repository username
   --repo, -r <repo>           Repository name
-  --token, -t <token>         GitHub token
+  --token, -t <token>         Access token (defaults to GITHUB_TOKEN, GITLAB_TOKEN or HF_TOKEN)
   --branch, -b <branch>       Branch name
   --commit, -c <commit>       Commit ID
+  --tag <tag>                 Tag name
+  --version <constraint>      Highest tag matching a semver constraint (e.g., ^1.4, ~1.4.2, ">=1.2 <2", latest)
+  --release <tag>             Download the assets of a release (tag name or latest) instead of files
+  --asset <glob>              Only download release assets matching a glob (e.g., "*linux*.tar.gz")
   --path, -p <path>           Path in repository
-  --output, -o <dir>          Output directory (default: .)
-  --format, -f <format>       File formats to include (e.g., image, [jpg,pdf,png], or "" for no-extension files)
+  --output, -o <dir>          Output directory (default: .), or - to write an extracted line range to stdout
+  --lines <range>             Only extract lines of a file (e.g., 10-42), like a #L10-L42 URL anchor
+  --permalink-header          Start an extracted line range with a comment noting its source permalink
+  --format, -f <format>       File formats to include or exclude (e.g., image,document, code,-json, !archive, [jpg,tar.gz], or "" for no-extension files)
+  --sniff                     Also match --format on file content, fetching the first bytes of each file
+  --config <file>             Format config to merge over the built-in categories (default: ~/.config/dgf/format.json)
+  --include <glob>            Only include files matching a gitignore-style glob (repeatable, e.g., 'docs/**/*.md')
+  --exclude <glob>            Exclude files and folders matching a gitignore-style glob (repeatable, e.g., '**/node_modules/**')
+  --min-size <size>           Skip files smaller than a size (e.g., 10KB)
+  --max-size <size>           Skip files larger than a size (e.g., 100MB)
+  --max-total <size>          Abort, or ask on a terminal, if the selected files exceed a size (e.g., 2GB)
   --no-print, -n              Suppress all output
   --print-tree                Print directory tree
   --check                     Check if path exists
   --print-info, -i            Print repository info as JSON
+  --archive <file>            Write files into a .tar.gz, .tar or .zip archive instead of a directory (- for tar.gz on stdout)
+  --tarball                   Download the repository archive in one request and extract the path
+  --jobs, -j <n>              Number of parallel downloads (default: 4)
+  --wait-rate-limit           Wait for an exhausted API rate limit to reset instead of failing
+  --verify-sha                Verify downloaded files against their git blob SHA
+  --verify <dir>              Re-check files of a previous download in <dir> against the repository,
+                              or without a URL against its .dgf.lock
+  --delete-mismatch           Delete files that fail verification
+  --sync                      Only download files that are new or changed in the output directory
+  --delete                    With --sync, remove local files that no longer exist upstream
   --help, -h                  Show this help message
 
 Note: Only one of --no-print, --print-tree, --check, or --print-info can be provided.
@@ -48,16 +77,38 @@ Note: Only one of --no-print, --print-tree, --check, or --print-info can be prov
 	pflag.StringVarP(&args.Site, "site", "s", "", "Platform ID (e.g., github, gitlab, huggingface)")
 	pflag.StringVarP(&args.Username, "username", "u", "", "Repository username")
 	pflag.StringVarP(&args.Repo, "repo", "r", "", "Repository name")
-	pflag.StringVarP(&args.Token, "token", "t", "", "GitHub token")
+	pflag.StringVarP(&args.Token, "token", "t", "", "Access token")
 	pflag.StringVarP(&args.Branch, "branch", "b", "", "Branch name")
 	pflag.StringVarP(&args.Commit, "commit", "c", "", "Commit ID")
+	pflag.StringVar(&args.Tag, "tag", "", "Tag name")
+	pflag.StringVar(&args.Version, "version", "", "Highest tag matching a semver constraint")
+	pflag.StringVar(&args.Release, "release", "", "Download the assets of a release (tag name or latest)")
+	pflag.StringVar(&args.Asset, "asset", "", "Only download release assets matching a glob")
 	pflag.StringVarP(&args.Path, "path", "p", "", "Path in repository")
 	pflag.StringVarP(&args.Output, "output", "o", ".", "Output directory for downloads (default: current directory)")
-	pflag.StringVarP(&format, "format", "f", "", "File formats to include (e.g., image, [jpg,png,pdf])")
+	pflag.StringVar(&args.Lines, "lines", "", "Only extract lines of a file (e.g., 10-42)")
+	pflag.BoolVar(&args.PermalinkHeader, "permalink-header", false, "Start an extracted line range with a comment noting its source permalink")
+	pflag.StringVarP(&format, "format", "f", "", "File formats to include or exclude (e.g., image,document, code,-json, !archive)")
+	pflag.BoolVar(&args.Sniff, "sniff", false, "Also match --format on file content")
+	pflag.StringVar(&args.Config, "config", "", "Format config to merge over the built-in categories")
+	pflag.StringArrayVar(&args.Include, "include", nil, "Only include files matching a glob (repeatable)")
+	pflag.StringArrayVar(&args.Exclude, "exclude", nil, "Exclude files and folders matching a glob (repeatable)")
+	pflag.StringVar(&minSize, "min-size", "", "Skip files smaller than a size (e.g., 10KB)")
+	pflag.StringVar(&maxSize, "max-size", "", "Skip files larger than a size (e.g., 100MB)")
+	pflag.StringVar(&maxTotal, "max-total", "", "Abort if the selected files exceed a size (e.g., 2GB)")
 	pflag.BoolVarP(&args.NoPrint, "no-print", "n", false, "Suppress all output")
 	pflag.BoolVar(&args.PrintTree, "print-tree", false, "Print directory tree")
 	pflag.BoolVar(&args.Check, "check", false, "Check if path exists")
 	pflag.BoolVarP(&args.PrintInfo, "print-info", "i", false, "Print info as JSON")
+	pflag.StringVar(&args.Archive, "archive", "", "Write files into a .tar.gz, .tar or .zip archive (- for tar.gz on stdout)")
+	pflag.BoolVar(&args.Tarball, "tarball", false, "Download the repository archive and extract the path")
+	pflag.IntVarP(&args.Jobs, "jobs", "j", 4, "Number of parallel downloads")
+	pflag.BoolVar(&args.WaitLimit, "wait-rate-limit", false, "Wait for an exhausted API rate limit to reset")
+	pflag.BoolVar(&args.VerifySha, "verify-sha", false, "Verify downloaded files against their git blob SHA")
+	pflag.StringVar(&args.VerifyDir, "verify", "", "Re-check files of a previous download in <dir>")
+	pflag.BoolVar(&args.DeleteMismatch, "delete-mismatch", false, "Delete files that fail verification")
+	pflag.BoolVar(&args.Sync, "sync", false, "Only download files that are new or changed")
+	pflag.BoolVar(&args.Delete, "delete", false, "With --sync, remove local files that no longer exist upstream")
 
 	// Help flag
 	help := pflag.BoolP("help", "h", false, "Show this help message")
@@ -89,10 +140,155 @@ Note: Only one of --no-print, --print-tree, --check, or --print-info can be prov
 		os.Exit(1)
 	}
 
-	// Validate input: either URL or site args, but not both
-	hasSiteArgs := args.Site != "" || args.Username != "" || args.Repo != ""
-	hasURL := pflag.NArg() == 1
-	if (hasSiteArgs && hasURL) || (!hasSiteArgs && !hasURL) {
+	// Validate that at most one ref is selected
+	refs := 0
+	for _, ref := range []string{args.Branch, args.Commit, args.Tag, args.Version} {
+		if ref != "" {
+			refs++
+		}
+	}
+	if refs > 1 {
+		fmt.Fprintf(os.Stderr, "Error: Only one of --branch, --commit, --tag, or --version can be provided\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+	if args.Version != "" {
+		if _, err := utils.ParseConstraint(args.Version); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Releases are downloaded as a set of assets rather than from a ref and path
+	if args.Release != "" && (refs > 0 || args.Path != "" || args.Tarball) {
+		fmt.Fprintf(os.Stderr, "Error: --release cannot be combined with --branch, --commit, --tag, --version, --path, or --tarball\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	// Line ranges are extracted from a single file instead of downloading it
+	if args.Lines != "" {
+		if _, _, ok := utils.ParseLineRange(args.Lines); !ok {
+			fmt.Fprintf(os.Stderr, "Error: Invalid line range '%s' (expected e.g. 10 or 10-42)\n", args.Lines)
+			os.Exit(1)
+		}
+		if args.Tarball || args.Sync || args.Release != "" || args.VerifyDir != "" {
+			fmt.Fprintf(os.Stderr, "Error: --lines cannot be combined with --tarball, --sync, --release, or --verify\n")
+			pflag.Usage()
+			os.Exit(1)
+		}
+	}
+
+	// Archives replace the output directory
+	if args.Archive != "" {
+		if _, err := provider.ArchiveKind(args.Archive); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if args.Tarball || args.Sync || args.VerifyDir != "" || args.VerifySha || args.Lines != "" {
+			fmt.Fprintf(os.Stderr, "Error: --archive cannot be combined with --tarball, --sync, --verify, --verify-sha, or --lines\n")
+			pflag.Usage()
+			os.Exit(1)
+		}
+	}
+
+	// Validate glob filters
+	for _, pattern := range append(append([]string{}, args.Include...), args.Exclude...) {
+		if err := utils.ValidGlob(pattern); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Parse size filters and the download budget
+	for _, size := range []struct {
+		value  string
+		target *int64
+	}{{minSize, &args.MinSize}, {maxSize, &args.MaxSize}, {maxTotal, &args.MaxTotal}} {
+		if size.value == "" {
+			continue
+		}
+		bytes, err := utils.ParseSize(size.value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		*size.target = bytes
+	}
+	if args.MaxSize > 0 && args.MinSize > args.MaxSize {
+		fmt.Fprintf(os.Stderr, "Error: --min-size cannot be larger than --max-size\n")
+		os.Exit(1)
+	}
+	if args.MaxTotal > 0 && args.Tarball {
+		fmt.Fprintf(os.Stderr, "Error: --max-total cannot be combined with --tarball, which does not list file sizes up front\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate parallelism
+	if args.Jobs < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --jobs must be at least 1\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	// Tarball mode only downloads, it never lists the repository
+	if args.Tarball && (args.PrintTree || args.Check || args.PrintInfo || args.VerifyDir != "") {
+		fmt.Fprintf(os.Stderr, "Error: --tarball cannot be combined with --print-tree, --check, --print-info, or --verify\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+	if args.Delete && !args.Sync {
+		fmt.Fprintf(os.Stderr, "Error: --delete can only be used with --sync\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+	if args.Sync && (args.Tarball || args.VerifyDir != "") {
+		fmt.Fprintf(os.Stderr, "Error: --sync cannot be combined with --tarball or --verify\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+	if args.VerifyDir != "" && (args.PrintTree || args.Check || args.PrintInfo) {
+		fmt.Fprintf(os.Stderr, "Error: --verify cannot be combined with --print-tree, --check, or --print-info\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	// Subcommands are given as the first positional argument; update takes the
+	// directory of a previous download instead of a URL
+	positional := pflag.Args()
+	if len(positional) == 1 && positional[0] == "formats" {
+		args.Command = positional[0]
+		return args
+	}
+	if len(positional) > 0 && positional[0] == "cat" {
+		args.Command = positional[0]
+		positional = positional[1:]
+		if args.Tarball || args.Sync || args.VerifyDir != "" || args.Archive != "" || args.PrintTree || args.Check || args.PrintInfo {
+			fmt.Fprintf(os.Stderr, "Error: cat cannot be combined with --tarball, --sync, --verify, --archive, --print-tree, --check, or --print-info\n")
+			pflag.Usage()
+			os.Exit(1)
+		}
+	}
+	if len(positional) > 0 && positional[0] == "update" {
+		args.Command = positional[0]
+		positional = positional[1:]
+		if len(positional) == 0 {
+			positional = []string{args.Output}
+		}
+		if args.Tarball || args.Sync || args.VerifyDir != "" || args.Archive != "" || args.PrintTree || args.Check || args.PrintInfo {
+			fmt.Fprintf(os.Stderr, "Error: update cannot be combined with --tarball, --sync, --verify, --archive, --print-tree, --check, or --print-info\n")
+			pflag.Usage()
+			os.Exit(1)
+		}
+	}
+
+	// Validate input: either URL or site args, but not both.
+	// --site alone may accompany a URL to pick the platform of a self-hosted instance.
+	hasSiteArgs := args.Username != "" || args.Repo != "" || (args.Site != "" && len(positional) == 0)
+	hasURL := len(positional) == 1
+	// --verify alone re-checks a previous download against its lockfile
+	if (hasSiteArgs && hasURL) || (!hasSiteArgs && !hasURL && args.VerifyDir == "") {
 		fmt.Fprintf(os.Stderr, "Error: Must provide either a URL or all of --site, --username, and --repo\n")
 		pflag.Usage()
 		os.Exit(1)
@@ -107,58 +303,55 @@ Note: Only one of --no-print, --print-tree, --check, or --print-info can be prov
 		}
 	}
 
-	// Set URL from positional argument if provided
+	// Set URL (or the directory to update) from positional argument if provided
 	if hasURL {
-		args.URL = pflag.Arg(0)
+		if args.Command == "update" {
+			args.Output = positional[0]
+		} else {
+			args.URL = positional[0]
+		}
 	}
 
-	// Process --format flag using embedded config/format.json
+	// --asset only applies to releases, given by flag or URL
+	if args.Asset != "" && args.Release == "" && !strings.Contains(args.URL, "/releases/") {
+		fmt.Fprintf(os.Stderr, "Error: --asset can only be used with --release or a releases URL\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
+	// Process --format flag using config/format.json and the user format config
 	if format != "" {
-		if format == `""` || format == "" {
-			// Handle -f "" or -f=""
-			args.Formats = []string{""}
-		} else {
-			// Parse embedded formats configuration
-			var formatsMap map[string]map[string][]string
-			if err := json.Unmarshal(formatsData, &formatsMap); err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing embedded format config: %v\n", err)
-				os.Exit(1)
-			}
-
-			// Check if format is a category (e.g., "image")
-			if formats, exists := formatsMap["formats"][format]; exists {
-				// Ensure all extensions are lowercase
-				for i, ext := range formats {
-					formats[i] = strings.ToLower(ext)
-				}
-				args.Formats = formats
-			} else {
-				// Parse as a list (e.g., "[jpg,pdf,png]")
-				cleanFormat := strings.Trim(format, "[]")
-				if cleanFormat != "" {
-					extensions := strings.Split(cleanFormat, ",")
-					for i, ext := range extensions {
-						extensions[i] = strings.TrimSpace(strings.ToLower(ext))
-					}
-					args.Formats = extensions
-				} else {
-					fmt.Fprintf(os.Stderr, "Error: Invalid format '%s'\n", format)
-					os.Exit(1)
-				}
-			}
+		// Load the embedded categories with the user config merged over them
+		formatsConfig, err := utils.LoadFormats(formatsData, args.Config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Parse categories and extensions to include or exclude (e.g., "image,document", "code,-json")
+		args.Formats, args.ExcludeFormats, err = utils.ParseFormats(format, formatsConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 	}
 
+	// Sniffing classifies listed files for the format filter
+	if args.Sniff && len(args.Formats) == 0 && len(args.ExcludeFormats) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: --sniff requires --format\n")
+		os.Exit(1)
+	}
+	if args.Sniff && args.Tarball {
+		fmt.Fprintf(os.Stderr, "Error: --sniff cannot be combined with --tarball\n")
+		pflag.Usage()
+		os.Exit(1)
+	}
+
 	// Normalize path by trimming slashes
 	if args.Path != "" {
 		args.Path = strings.Trim(args.Path, "/")
 	}
 
-	// Set token from environment if not provided
-	if args.Token == "" {
-		args.Token = os.Getenv("GITHUB_TOKEN")
-	}
-
 	// Normalize output directory
 	if args.Output != "" {
 		args.Output = strings.TrimRight(args.Output, "/")
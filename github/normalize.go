@@ -0,0 +1,122 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// normalizeURL rewrites the forms GitHub links come in to the github.com web URL they
+// point at, so that only one URL layout has to be parsed:
+//
+//	owner/repo[/path][@ref]                        shorthand
+//	git@github.com:owner/repo.git                  SSH clone URL
+//	https://github.com/owner/repo.git              HTTPS clone URL
+//	github.com/..., www.github.com/..., github.dev/...
+//	raw.githubusercontent.com/owner/repo/<ref>/<path>
+//	api.github.com/repos/owner/repo/contents/<path>?ref=<ref>
+//
+// Forms that name a path but no ref have no web URL equivalent; their path is
+// returned separately and the default branch applies.
+func normalizeURL(rawURL string) (string, string, error) {
+	if isShorthand(rawURL) {
+		return normalizeShorthand(rawURL)
+	}
+
+	// SSH clone URLs
+	for _, prefix := range []string{"git@github.com:", "ssh://git@github.com/"} {
+		if strings.HasPrefix(rawURL, prefix) {
+			rawURL = "https://github.com/" + strings.TrimPrefix(rawURL, prefix)
+		}
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("invalid URL format: %s", rawURL)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch strings.ToLower(u.Host) {
+	case "github.com", "www.github.com", "github.dev":
+	case "raw.githubusercontent.com":
+		// owner/repo/[refs/heads/|refs/tags/]<ref>/<path> is always a file
+		if len(segments) < 4 {
+			return "", "", fmt.Errorf("invalid raw URL structure: missing ref or path")
+		}
+		rest := segments[2:]
+		if len(rest) > 3 && rest[0] == "refs" && (rest[1] == "heads" || rest[1] == "tags") {
+			rest = rest[2:]
+		}
+		segments = append([]string{segments[0], segments[1], "blob"}, rest...)
+	case "api.github.com":
+		// repos/owner/repo[/contents/<path>][?ref=<ref>]
+		if len(segments) < 3 || segments[0] != "repos" {
+			return "", "", fmt.Errorf("invalid API URL structure: expected /repos/<owner>/<repo>")
+		}
+		segments = segments[1:]
+		var path string
+		if len(segments) > 3 && segments[2] == "contents" {
+			path = strings.Join(segments[3:], "/")
+		}
+		segments = segments[:2]
+		if ref := u.Query().Get("ref"); ref != "" {
+			segments = append(segments, "tree", ref)
+			if path != "" {
+				segments = append(segments, path)
+			}
+			path = ""
+		}
+		return joinWebURL(segments), path, nil
+	default:
+		return "", "", fmt.Errorf("invalid URL format: does not match GitHub site")
+	}
+
+	return joinWebURL(segments), "", nil
+}
+
+// isShorthand reports whether rawURL is owner/repo shorthand rather than a URL.
+// GitHub owner names cannot contain dots, unlike host names.
+func isShorthand(rawURL string) bool {
+	if strings.Contains(rawURL, "://") || strings.HasPrefix(rawURL, "git@") {
+		return false
+	}
+	segments := strings.Split(rawURL, "/")
+	return len(segments) >= 2 && segments[0] != "" && !strings.Contains(segments[0], ".")
+}
+
+// normalizeShorthand rewrites owner/repo[/path][@ref] to a web URL
+func normalizeShorthand(rawURL string) (string, string, error) {
+	spec, ref := rawURL, ""
+	if idx := strings.LastIndex(rawURL, "@"); idx >= 0 {
+		spec, ref = rawURL[:idx], rawURL[idx+1:]
+		if ref == "" {
+			return "", "", fmt.Errorf("invalid shorthand %s: empty ref after @", rawURL)
+		}
+	}
+
+	segments := strings.Split(strings.Trim(spec, "/"), "/")
+	if len(segments) < 2 || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid shorthand %s: expected <owner>/<repo>[/<path>][@<ref>]", rawURL)
+	}
+	path := strings.Join(segments[2:], "/")
+	segments = segments[:2]
+	if ref != "" {
+		segments = append(segments, "tree", ref)
+		if path != "" {
+			segments = append(segments, path)
+		}
+		path = ""
+	}
+	return joinWebURL(segments), path, nil
+}
+
+// joinWebURL builds a github.com URL from path segments, dropping the .git suffix of clone URLs
+func joinWebURL(segments []string) string {
+	if len(segments) >= 2 {
+		segments[1] = strings.TrimSuffix(segments[1], ".git")
+	}
+	return "https://github.com/" + strings.Join(segments, "/")
+}
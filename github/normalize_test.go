@@ -0,0 +1,104 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		rawURL   string
+		wantURL  string
+		wantPath string
+		wantErr  bool
+	}{
+		// Web URLs pass through, with or without a scheme
+		{"https://github.com/o/r", "https://github.com/o/r", "", false},
+		{"https://github.com/o/r/blob/main/src/a.go", "https://github.com/o/r/blob/main/src/a.go", "", false},
+		{"https://github.com/o/r/tree/v1.0/docs", "https://github.com/o/r/tree/v1.0/docs", "", false},
+		{"https://github.com/o/r/releases/tag/v1.0", "https://github.com/o/r/releases/tag/v1.0", "", false},
+		{"https://github.com/o/r/releases/download/v1.0/dgf.zip", "https://github.com/o/r/releases/download/v1.0/dgf.zip", "", false},
+		{"github.com/o/r/tree/main", "https://github.com/o/r/tree/main", "", false},
+		{"www.github.com/o/r", "https://github.com/o/r", "", false},
+		{"https://github.dev/o/r/blob/main/a.go", "https://github.com/o/r/blob/main/a.go", "", false},
+
+		// Clone URLs
+		{"https://github.com/o/r.git", "https://github.com/o/r", "", false},
+		{"git@github.com:o/r.git", "https://github.com/o/r", "", false},
+		{"ssh://git@github.com/o/r.git", "https://github.com/o/r", "", false},
+
+		// Raw URLs always name a file
+		{"https://raw.githubusercontent.com/o/r/main/src/a.go", "https://github.com/o/r/blob/main/src/a.go", "", false},
+		{"https://raw.githubusercontent.com/o/r/refs/heads/main/a.go", "https://github.com/o/r/blob/main/a.go", "", false},
+		{"https://raw.githubusercontent.com/o/r/refs/tags/v1.0/a.go", "https://github.com/o/r/blob/v1.0/a.go", "", false},
+		{"https://raw.githubusercontent.com/o/r/main", "", "", true},
+
+		// API URLs name their ref in the query
+		{"https://api.github.com/repos/o/r", "https://github.com/o/r", "", false},
+		{"https://api.github.com/repos/o/r/contents/docs/a.md", "https://github.com/o/r", "docs/a.md", false},
+		{"https://api.github.com/repos/o/r/contents/docs?ref=dev", "https://github.com/o/r/tree/dev/docs", "", false},
+		{"https://api.github.com/users/o", "", "", true},
+
+		// owner/repo shorthand
+		{"o/r", "https://github.com/o/r", "", false},
+		{"o/r/docs/a.md", "https://github.com/o/r", "docs/a.md", false},
+		{"o/r@v1.0", "https://github.com/o/r/tree/v1.0", "", false},
+		{"o/r/docs@dev", "https://github.com/o/r/tree/dev/docs", "", false},
+		{"o/r@", "", "", true},
+
+		// Other hosts
+		{"https://gitlab.com/o/r", "", "", true},
+	}
+	for _, tt := range tests {
+		gotURL, gotPath, err := normalizeURL(tt.rawURL)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeURL(%q): error %v, wantErr %v", tt.rawURL, err, tt.wantErr)
+			continue
+		}
+		if gotURL != tt.wantURL || gotPath != tt.wantPath {
+			t.Errorf("normalizeURL(%q) = %q, %q, want %q, %q", tt.rawURL, gotURL, gotPath, tt.wantURL, tt.wantPath)
+		}
+	}
+}
+
+func TestParseGitHubURL(t *testing.T) {
+	platform := types.Platform{
+		Name: "GitHub",
+		ID:   "github",
+		URL:  types.URL{Site: []string{"https://github.com"}},
+	}
+	tests := []struct {
+		rawURL string
+		want   types.ParsedURL
+	}{
+		{"https://github.com/o/r/blob/main/src/a.go#L10", types.ParsedURL{
+			Branch: "main", RefPath: "main/src/a.go", Path: "src/a.go", ParentPath: "src", RequestPath: "a.go", LineStart: 10, LineEnd: 10,
+		}},
+		{"https://github.com/o/r/blob/main/a.go#L10-L42", types.ParsedURL{
+			Branch: "main", RefPath: "main/a.go", Path: "a.go", RequestPath: "a.go", LineStart: 10, LineEnd: 42,
+		}},
+		{"https://github.com/o/r/blob/main/README.md#readme", types.ParsedURL{
+			Branch: "main", RefPath: "main/README.md", Path: "README.md", RequestPath: "README.md",
+		}},
+		{"https://github.com/o/r/tree/0123abcd/docs", types.ParsedURL{
+			Commit: "0123abcd", Path: "docs", RequestPath: "docs",
+		}},
+		{"https://github.com/o/r/releases/tag/v1.0", types.ParsedURL{Release: "v1.0"}},
+		{"https://github.com/o/r/releases/latest", types.ParsedURL{Release: "latest"}},
+		{"https://github.com/o/r/releases/download/v1.0/dgf.zip", types.ParsedURL{Release: "v1.0", Asset: "dgf.zip"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseGitHubURL(tt.rawURL, platform, types.Args{})
+		if err != nil {
+			t.Errorf("ParseGitHubURL(%q): %v", tt.rawURL, err)
+			continue
+		}
+		want := tt.want
+		want.URL, want.Name, want.ID, want.Username, want.Repo = tt.rawURL, "GitHub", "github", "o", "r"
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseGitHubURL(%q) = %+v, want %+v", tt.rawURL, got, want)
+		}
+	}
+}
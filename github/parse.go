@@ -1,12 +1,12 @@
 package github
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/NeerajCodz/dgf/provider"
 	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
 )
 
 // ParseGitHubURL parses a GitHub URL or constructs one from site arguments
@@ -18,7 +18,7 @@ func ParseGitHubURL(url string, platform types.Platform, args types.Args) (types
 	}
 
 	// Check if site arguments are provided
-	hasSiteArgs := args.Site != "" || args.Username != "" || args.Repo != ""
+	hasSiteArgs := args.Username != "" || args.Repo != ""
 	hasURL := url != ""
 
 	// If site args are provided, construct the URL
@@ -27,36 +27,17 @@ func ParseGitHubURL(url string, platform types.Platform, args types.Args) (types
 			return result, fmt.Errorf("must provide all of --site, --username, and --repo")
 		}
 
-		// Read config/git.json to validate platform
-		configData, err := os.ReadFile("config/git.json")
-		if err != nil {
-			return result, fmt.Errorf("failed to read config file: %v", err)
-		}
-
-		var platforms []types.Platform
-		if err := json.Unmarshal(configData, &platforms); err != nil {
-			return result, fmt.Errorf("failed to parse config file: %v", err)
-		}
-
-		// Find matching platform (case-insensitive)
-		siteID := strings.ToLower(args.Site)
-		var selectedPlatform types.Platform
-		for _, p := range platforms {
-			if p.ID == siteID {
-				selectedPlatform = p
-				break
-			}
-		}
-		if selectedPlatform.ID == "" {
+		// Validate that the site matches this platform (case-insensitive)
+		if strings.ToLower(args.Site) != platform.ID {
 			return result, fmt.Errorf("invalid site ID '%s'", args.Site)
 		}
 
 		// Construct base URL
-		result.URL = selectedPlatform.URLStruc.Site
+		result.URL = platform.URLStruc.Site
 		result.URL = strings.ReplaceAll(result.URL, "<username>", args.Username)
 		result.URL = strings.ReplaceAll(result.URL, "<repo>", args.Repo)
-		result.Name = selectedPlatform.Name
-		result.ID = selectedPlatform.ID
+		result.Name = platform.Name
+		result.ID = platform.ID
 		result.Username = args.Username
 		result.Repo = args.Repo
 		return result, nil
@@ -64,11 +45,16 @@ func ParseGitHubURL(url string, platform types.Platform, args types.Args) (types
 
 	// If URL is provided, parse it
 	if hasURL {
-		normalizedURL := url
-		if strings.HasPrefix(url, "github.com/") {
-			normalizedURL = "https://" + url
-		} else if !strings.HasPrefix(url, "http://github.com/") && !strings.HasPrefix(url, "https://github.com/") {
-			return result, fmt.Errorf("invalid URL format: does not match GitHub site")
+		// Capture #L10 and #L10-L42 line anchors; other fragments are ignored
+		url, fragment, _ := strings.Cut(url, "#")
+		if start, end, ok := utils.ParseLineRange(fragment); ok && strings.HasPrefix(fragment, "L") {
+			result.LineStart, result.LineEnd = start, end
+		}
+
+		// Rewrite clone, raw, API, github.dev and shorthand forms to a web URL
+		normalizedURL, refLessPath, err := normalizeURL(url)
+		if err != nil {
+			return result, err
 		}
 
 		var baseURL string
@@ -92,13 +78,30 @@ func ParseGitHubURL(url string, platform types.Platform, args types.Args) (types
 		result.Username = segments[0]
 		result.Repo = segments[1]
 
+		// Parse releases/tag/<tag>, releases/latest and releases/download/<tag>/<asset>
+		if len(segments) >= 3 && segments[2] == "releases" {
+			if len(segments) >= 5 && (segments[3] == "tag" || segments[3] == "download") {
+				result.Release = segments[4]
+				if segments[3] == "download" && len(segments) > 5 {
+					result.Asset = strings.Join(segments[5:], "/")
+				}
+			} else {
+				result.Release = "latest"
+			}
+			return result, nil
+		}
+
 		// Parse branch or commit and path if present
 		if len(segments) >= 4 && (segments[2] == "blob" || segments[2] == "tree") {
 			// Check if segments[3] is a commit hash (e.g., 40 characters for SHA-1)
-			if len(segments[3]) >= 7 && isPotentialCommitHash(segments[3]) {
+			if utils.IsPotentialCommitHash(segments[3], 7) {
 				result.Commit = segments[3]
 			} else {
 				result.Branch = segments[3]
+				if len(segments) > 4 {
+					// The branch may contain slashes; the provider splits it off later
+					result.RefPath = strings.Join(segments[3:], "/")
+				}
 			}
 			if len(segments) > 4 {
 				fullPath := strings.Join(segments[4:], "/")
@@ -113,21 +116,12 @@ func ParseGitHubURL(url string, platform types.Platform, args types.Args) (types
 				result.Path = fullPath
 			}
 		}
+
+		// Forms without a ref name their path separately
+		if refLessPath != "" {
+			provider.SetPath(&result, refLessPath)
+		}
 	}
 
 	return result, nil
 }
-
-// isPotentialCommitHash checks if a string could be a Git commit hash
-func isPotentialCommitHash(s string) bool {
-	// Git commit hashes are typically 40 characters (SHA-1) or at least 7 characters for short hashes
-	if len(s) < 7 || len(s) > 40 {
-		return false
-	}
-	for _, c := range s {
-		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
-			return false
-		}
-	}
-	return true
-}
\ No newline at end of file
@@ -3,88 +3,135 @@ package github
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
 )
 
-var ErrPathNotFound = fmt.Errorf("path not found")
+// fetchDefaultBranch retrieves the default branch of a GitHub repository
+func fetchDefaultBranch(owner, repo, token string) (string, error) {
+	api := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+	req, err := http.NewRequest("GET", api, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Add("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Add("Authorization", "token "+token)
+	}
 
-// ProcessGitHubURL processes a GitHub URL or site args, fetching the repository structure
-func ProcessGitHubURL(url, token, branch, commit, path string, platform types.Platform, args types.Args) (types.ParsedURL, types.RepositoryStructure, error) {
-	// Parse the URL or construct it from site args
-	parsed, err := ParseGitHubURL(url, platform, args)
+	resp, err := utils.Client.Do(req)
 	if err != nil {
-		return parsed, types.RepositoryStructure{}, fmt.Errorf("failed to parse URL: %v", err)
-	}
-
-	// Override path if provided via --path
-	if path != "" {
-		parsed.Path = path
-		pathSegments := strings.Split(path, "/")
-		if len(pathSegments) > 1 {
-			parsed.ParentPath = strings.Join(pathSegments[:len(pathSegments)-1], "/")
-			parsed.RequestPath = pathSegments[len(pathSegments)-1]
-		} else {
-			parsed.ParentPath = ""
-			parsed.RequestPath = path
-		}
+		return "", fmt.Errorf("failed to fetch repo info: %v", err)
 	}
+	defer resp.Body.Close()
 
-	// Determine the reference (commit or branch)
-	var ref string
-	if commit != "" {
-		ref = commit
-		parsed.Commit = commit
-		parsed.Branch = ""
-	} else if parsed.Commit != "" {
-		ref = parsed.Commit
-	} else if branch != "" {
-		ref = branch
-		parsed.Branch = branch
-	} else if parsed.Branch != "" {
-		ref = parsed.Branch
-	} else {
-		defaultBranch, err := fetchDefaultBranch(parsed.Username, parsed.Repo, token)
-		if err != nil {
-			return parsed, types.RepositoryStructure{}, fmt.Errorf("failed to fetch default branch: %v", err)
-		}
-		ref = defaultBranch
-		parsed.Branch = defaultBranch
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("failed to fetch repo info: status %d - check repository owner (%s), repo (%s), or token permissions", resp.StatusCode, owner, repo)
 	}
 
-	// Reconstruct the parsed URL with ref and path
-	if parsed.Path != "" {
-		parsed.URL = fmt.Sprintf("https://github.com/%s/%s/tree/%s/%s", parsed.Username, parsed.Repo, ref, parsed.Path)
-	} else {
-		parsed.URL = fmt.Sprintf("https://github.com/%s/%s/tree/%s", parsed.Username, parsed.Repo, ref)
+	var repoInfo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
+		return "", fmt.Errorf("failed to decode repo info: %v", err)
 	}
 
-	// Determine request type if a path is specified
-	if parsed.Path != "" {
-		requestType, err := getRequestType(url, parsed.Username, parsed.Repo, ref, parsed.ParentPath, parsed.RequestPath, token)
-		if err != nil {
-			return parsed, types.RepositoryStructure{}, fmt.Errorf("failed to determine request type for path %s: %v", parsed.Path, err)
-		}
-		parsed.RequestType = requestType
+	if repoInfo.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found for %s/%s", owner, repo)
 	}
 
-	// Fetch the repository structure, passing args for format filtering
-	structure, err := FetchGitHubStructure(parsed.Username, parsed.Repo, ref, parsed.Path, parsed.RequestType, token, args)
+	return repoInfo.DefaultBranch, nil
+}
+
+// fetchCommitSha resolves a branch, tag or short commit to its full commit SHA
+func fetchCommitSha(owner, repo, ref, token string) (string, error) {
+	api := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s", owner, repo, url.PathEscape(ref))
+	req, err := http.NewRequest("GET", api, nil)
 	if err != nil {
-		return parsed, structure, err
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// The sha media type returns the bare commit SHA instead of the full commit
+	req.Header.Add("Accept", "application/vnd.github.sha")
+	if token != "" {
+		req.Header.Add("Authorization", "token "+token)
 	}
 
-	return parsed, structure, nil
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch commit: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 || resp.StatusCode == 422 {
+		return "", fmt.Errorf("ref %s not found in %s/%s", ref, owner, repo)
+	} else if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
+	}
+
+	sha, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit: %v", err)
+	}
+
+	return strings.TrimSpace(string(sha)), nil
 }
 
-// fetchDefaultBranch retrieves the default branch of a GitHub repository
-func fetchDefaultBranch(owner, repo, token string) (string, error) {
-	api := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+// fetchTags lists the names of all tags in a GitHub repository, following pagination
+func fetchTags(owner, repo, token string) ([]string, error) {
+	var names []string
+	for page := 1; ; page++ {
+		api := fmt.Sprintf("https://api.github.com/repos/%s/%s/tags?per_page=100&page=%d", owner, repo, page)
+		req, err := http.NewRequest("GET", api, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		req.Header.Add("Accept", "application/vnd.github+json")
+		if token != "" {
+			req.Header.Add("Authorization", "token "+token)
+		}
+
+		resp, err := utils.Client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %v", err)
+		}
+
+		var tags []struct {
+			Name string `json:"name"`
+		}
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to fetch tags: status %d - check repository owner (%s), repo (%s), or token permissions", resp.StatusCode, owner, repo)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&tags)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tags: %v", err)
+		}
+
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		if len(tags) < 100 {
+			return names, nil
+		}
+	}
+}
+
+// fetchMatchingRefs lists the names of the refs of a kind (heads or tags) that start with prefix
+func fetchMatchingRefs(owner, repo, kind, prefix, token string) ([]string, error) {
+	api := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/matching-refs/%s/%s", owner, repo, kind, url.PathEscape(prefix))
 	req, err := http.NewRequest("GET", api, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Add("Accept", "application/vnd.github+json")
@@ -92,29 +139,28 @@ func fetchDefaultBranch(owner, repo, token string) (string, error) {
 		req.Header.Add("Authorization", "token "+token)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := utils.Client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch repo info: %v", err)
+		return nil, fmt.Errorf("failed to fetch refs: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch repo info: status %d - check repository owner (%s), repo (%s), or token permissions", resp.StatusCode, owner, repo)
+		return nil, fmt.Errorf("failed to fetch refs: status %d - check repository owner (%s), repo (%s), or token permissions", resp.StatusCode, owner, repo)
 	}
 
-	var repoInfo struct {
-		DefaultBranch string `json:"default_branch"`
+	var refs []struct {
+		Ref string `json:"ref"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
-		return "", fmt.Errorf("failed to decode repo info: %v", err)
+	if err := json.NewDecoder(resp.Body).Decode(&refs); err != nil {
+		return nil, fmt.Errorf("failed to decode refs: %v", err)
 	}
 
-	if repoInfo.DefaultBranch == "" {
-		return "", fmt.Errorf("no default branch found for %s/%s", owner, repo)
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, strings.TrimPrefix(ref.Ref, "refs/"+kind+"/"))
 	}
-
-	return repoInfo.DefaultBranch, nil
+	return names, nil
 }
 
 // PrintStructure prints the repository structure for debugging
@@ -129,4 +175,4 @@ func PrintStructure(structure types.RepositoryStructure) {
 	for _, folder := range structure.Folders {
 		fmt.Printf("  %s\n", folder)
 	}
-}
\ No newline at end of file
+}
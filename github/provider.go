@@ -0,0 +1,157 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+func init() {
+	provider.Register("github", New)
+}
+
+// Provider fetches repositories hosted on GitHub
+type Provider struct {
+	platform types.Platform
+	token    string
+}
+
+// New creates a GitHub provider, falling back to GITHUB_TOKEN when no token is given
+func New(platform types.Platform, token string) provider.Provider {
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	return &Provider{platform: platform, token: token}
+}
+
+// ParseURL parses a GitHub URL or constructs one from site arguments
+func (p *Provider) ParseURL(url string, args types.Args) (types.ParsedURL, error) {
+	return ParseGitHubURL(url, p.platform, args)
+}
+
+// WebURL builds the github.com tree URL for the parsed path at ref
+func (p *Provider) WebURL(parsed types.ParsedURL, ref string) string {
+	if parsed.Path != "" {
+		return fmt.Sprintf("https://github.com/%s/%s/tree/%s/%s", parsed.Username, parsed.Repo, ref, parsed.Path)
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/tree/%s", parsed.Username, parsed.Repo, ref)
+}
+
+// DefaultBranch retrieves the default branch of the repository
+func (p *Provider) DefaultBranch(parsed types.ParsedURL) (string, error) {
+	return fetchDefaultBranch(parsed.Username, parsed.Repo, p.token)
+}
+
+// ResolveCommit resolves ref to its full commit SHA
+func (p *Provider) ResolveCommit(parsed types.ParsedURL, ref string) (string, error) {
+	return fetchCommitSha(parsed.Username, parsed.Repo, ref, p.token)
+}
+
+// ListTags returns the names of all tags in the repository
+func (p *Provider) ListTags(parsed types.ParsedURL) ([]string, error) {
+	return fetchTags(parsed.Username, parsed.Repo, p.token)
+}
+
+// FetchRelease returns the release with the given tag, or the newest one for "latest"
+func (p *Provider) FetchRelease(parsed types.ParsedURL, release string) (provider.Release, error) {
+	return fetchRelease(parsed.Username, parsed.Repo, release, p.token)
+}
+
+// MatchRefs returns the names of the branches and tags that start with prefix
+func (p *Provider) MatchRefs(parsed types.ParsedURL, prefix string) ([]string, []string, error) {
+	branches, err := fetchMatchingRefs(parsed.Username, parsed.Repo, "heads", prefix, p.token)
+	if err != nil {
+		return nil, nil, err
+	}
+	tags, err := fetchMatchingRefs(parsed.Username, parsed.Repo, "tags", prefix, p.token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return branches, tags, nil
+}
+
+// StatPath determines whether the parsed path is a file or directory
+func (p *Provider) StatPath(parsed types.ParsedURL, ref string) (string, error) {
+	return getRequestType(parsed.URL, parsed.Username, parsed.Repo, ref, parsed.ParentPath, parsed.RequestPath, p.token)
+}
+
+// ListTree fetches the repository structure below the parsed path
+func (p *Provider) ListTree(parsed types.ParsedURL, ref string, args types.Args) (types.RepositoryStructure, error) {
+	return FetchGitHubStructure(parsed.Username, parsed.Repo, ref, parsed.Path, parsed.RequestType, p.token, args)
+}
+
+// OpenFile opens a download stream for a raw file URL, resuming at offset
+func (p *Provider) OpenFile(downloadURL string, offset int64) (*provider.FileStream, error) {
+	req, err := p.fileRequest(downloadURL)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenStream(req, offset)
+}
+
+// OpenPrefix opens the first length bytes of a file listed in DownloadURLs
+func (p *Provider) OpenPrefix(downloadURL string, length int64) (io.ReadCloser, error) {
+	req, err := p.fileRequest(downloadURL)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenPrefix(req, length)
+}
+
+// fileRequest builds the download request of a file listed in DownloadURLs
+func (p *Provider) fileRequest(downloadURL string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if p.token != "" {
+		req.Header.Add("Authorization", "token "+p.token)
+	}
+	if strings.Contains(downloadURL, "/releases/assets/") {
+		// The asset API redirects to the binary, pre-authorized for private repositories
+		req.Header.Add("Accept", "application/octet-stream")
+	} else {
+		req.Header.Add("Accept", "application/vnd.github+json")
+	}
+	return req, nil
+}
+
+// OpenTarball opens the .tar.gz archive of the repository at ref. Public archives
+// come straight from codeload; with a token the API endpoint is used, which
+// redirects private repositories to a pre-authorized codeload URL.
+func (p *Provider) OpenTarball(parsed types.ParsedURL, ref string) (io.ReadCloser, error) {
+	api := fmt.Sprintf("https://codeload.github.com/%s/%s/tar.gz/%s", parsed.Username, parsed.Repo, escapePath(ref))
+	if p.token != "" {
+		api = fmt.Sprintf("https://api.github.com/repos/%s/%s/tarball/%s", parsed.Username, parsed.Repo, escapePath(ref))
+	}
+	req, err := http.NewRequest("GET", api, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if p.token != "" {
+		req.Header.Add("Authorization", "token "+p.token)
+	}
+
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		resp.Body.Close()
+		return nil, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
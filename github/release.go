@@ -0,0 +1,81 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// gitHubRelease represents a release returned by the releases API
+type gitHubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name               string `json:"name"`
+		Size               int    `json:"size"`
+		URL                string `json:"url"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+		Digest             string `json:"digest"` // sha256:<hex>, absent on older assets
+	} `json:"assets"`
+}
+
+// fetchRelease retrieves a release by tag, or the newest release for "latest", with its assets
+func fetchRelease(owner, repo, release, token string) (provider.Release, error) {
+	api := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", owner, repo, url.PathEscape(release))
+	if release == "latest" {
+		api = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	}
+	req, err := http.NewRequest("GET", api, nil)
+	if err != nil {
+		return provider.Release{}, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Add("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Add("Authorization", "token "+token)
+	}
+
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return provider.Release{}, fmt.Errorf("failed to fetch release: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return provider.Release{}, fmt.Errorf("release %s not found in %s/%s", release, owner, repo)
+	} else if resp.StatusCode != 200 {
+		return provider.Release{}, fmt.Errorf("failed to fetch release: status %d - check repository owner (%s), repo (%s), or token permissions", resp.StatusCode, owner, repo)
+	}
+
+	var info gitHubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return provider.Release{}, fmt.Errorf("failed to decode release: %v", err)
+	}
+
+	result := provider.Release{Tag: info.TagName, URL: info.HTMLURL}
+	for _, asset := range info.Assets {
+		// Browser URLs only work for public repositories; with a token the asset API
+		// is used, which redirects to a pre-authorized download
+		downloadURL := asset.BrowserDownloadURL
+		if token != "" {
+			downloadURL = asset.URL
+		}
+		result.Assets = append(result.Assets, types.TreeEntry{
+			Name:        asset.Name,
+			Path:        asset.Name,
+			Type:        "file",
+			Size:        asset.Size,
+			Sha:         asset.Digest,
+			URL:         asset.URL,
+			HTMLURL:     asset.BrowserDownloadURL,
+			DownloadURL: downloadURL,
+		})
+	}
+
+	return result, nil
+}
@@ -5,63 +5,35 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"path/filepath"
 	"strings"
 
+	"github.com/NeerajCodz/dgf/provider"
 	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
 )
 
-// FetchGitHubStructure fetches the repository structure, filtering files by format if specified
+// FetchGitHubStructure fetches the repository structure, filtering files by format and globs if specified
 func FetchGitHubStructure(owner, repo, ref, path, requestType, token string, args types.Args) (types.RepositoryStructure, error) {
 	// Normalize owner and repo for API
 	owner = strings.ToLower(owner)
 	repo = strings.ToLower(repo)
 
-	// Determine parent path for relative path construction
-	var parentPath string
-	if path != "" {
-		pathSegments := strings.Split(path, "/")
-		if len(pathSegments) > 1 {
-			parentPath = strings.Join(pathSegments[:len(pathSegments)-1], "/")
-		}
-	}
-
 	// Initialize an empty repository structure
-	structure := types.RepositoryStructure{
-		Files:        []string{},
-		FilesName:    []string{},
-		FilesSha:     []string{},
-		FilesHTMLURL: []string{},
-		FilesGitURL:  []string{},
-		FilesURL:     []string{},
-		FilesSize:    []int{},
-		Folders:      []string{},
-		DownloadURLs: []string{},
-		FilesRequest: []string{},
-	}
+	structure := provider.NewStructure()
 
 	// Handle single file request
 	if requestType == "file" && path != "" {
 		content, err := fetchSingleFile(owner, repo, ref, path, token)
 		if err != nil {
-			if err == ErrPathNotFound {
-				return structure, ErrPathNotFound
+			if err == provider.ErrPathNotFound {
+				return structure, provider.ErrPathNotFound
 			}
 			return structure, fmt.Errorf("failed to fetch file details for %s: %v", path, err)
 		}
 
-		// Apply format filtering
-		if len(args.Formats) == 1 && args.Formats[0] == "" {
-			// -f "" means only files with no extension
-			if filepath.Ext(content.Name) != "" {
-				return structure, nil
-			}
-		} else if len(args.Formats) > 0 {
-			// -f image or -f [jpg,pdf]
-			ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(content.Name), "."))
-			if !contains(args.Formats, ext) {
-				return structure, nil
-			}
+		// Apply format, glob and size filtering
+		if !provider.MatchFile(path, args) || !provider.MatchSize(int64(content.Size), args) {
+			return structure, nil
 		}
 
 		// Populate structure with file details
@@ -82,87 +54,23 @@ func FetchGitHubStructure(owner, repo, ref, path, requestType, token string, arg
 		return structure, nil
 	}
 
-	// Fetch contents (root or specified path)
-	contents, err := FetchGitHubContents(owner, repo, ref, path, token)
+	// Resolve the tree of the requested path and list it with the Git Trees API
+	treeSha, err := resolveTreeSha(owner, repo, ref, path, token)
 	if err != nil {
-		if err == ErrPathNotFound {
-			return structure, ErrPathNotFound
+		if err == provider.ErrPathNotFound {
+			return structure, provider.ErrPathNotFound
 		}
-		return structure, fmt.Errorf("failed to fetch contents for path %s: %v", path, err)
+		return structure, fmt.Errorf("failed to resolve tree for path %s: %v", path, err)
 	}
-
-	// Process each item in the directory
-	for _, content := range contents {
-		itemPath := content.Path
-		var requestItemPath string
-		if parentPath != "" && strings.HasPrefix(itemPath, parentPath+"/") {
-			requestItemPath = strings.TrimPrefix(itemPath, parentPath+"/")
-		} else {
-			requestItemPath = itemPath
-		}
-
-		if content.Type == "file" {
-			// Apply format filtering
-			if len(args.Formats) == 1 && args.Formats[0] == "" {
-				// -f "" means only files with no extension
-				if filepath.Ext(content.Name) != "" {
-					continue
-				}
-			} else if len(args.Formats) > 0 {
-				// -f image or -f [jpg,pdf]
-				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(content.Name), "."))
-				if !contains(args.Formats, ext) {
-					continue
-				}
-			}
-
-			// Add file to structure
-			structure.Files = append(structure.Files, itemPath)
-			structure.FilesName = append(structure.FilesName, content.Name)
-			structure.FilesSha = append(structure.FilesSha, content.Sha)
-			structure.FilesHTMLURL = append(structure.FilesHTMLURL, content.HTMLURL)
-			structure.FilesGitURL = append(structure.FilesGitURL, content.GitURL)
-			structure.FilesURL = append(structure.FilesURL, content.URL)
-			structure.FilesSize = append(structure.FilesSize, content.Size)
-			if content.DownloadURL != nil {
-				structure.DownloadURLs = append(structure.DownloadURLs, *content.DownloadURL)
-			} else {
-				structure.DownloadURLs = append(structure.DownloadURLs, "")
-			}
-			structure.FilesRequest = append(structure.FilesRequest, requestItemPath)
-		} else if content.Type == "dir" {
-			// Add directory to structure only if it contains matching files
-			var folderRequestPath string
-			if parentPath != "" && strings.HasPrefix(itemPath, parentPath+"/") {
-				folderRequestPath = strings.TrimPrefix(itemPath, parentPath+"/")
-			} else {
-				folderRequestPath = itemPath
-			}
-
-			// Recursively fetch subdirectory contents
-			subStructure, err := FetchGitHubStructure(owner, repo, ref, itemPath, "dir", token, args)
-			if err != nil {
-				return structure, err
-			}
-
-			// Only add folder if it contains files or subfolders with matching files
-			if len(subStructure.Files) > 0 || len(subStructure.Folders) > 0 {
-				structure.Folders = append(structure.Folders, folderRequestPath)
-				structure.Files = append(structure.Files, subStructure.Files...)
-				structure.FilesName = append(structure.FilesName, subStructure.FilesName...)
-				structure.FilesSha = append(structure.FilesSha, subStructure.FilesSha...)
-				structure.FilesHTMLURL = append(structure.FilesHTMLURL, subStructure.FilesHTMLURL...)
-				structure.FilesGitURL = append(structure.FilesGitURL, subStructure.FilesGitURL...)
-				structure.FilesURL = append(structure.FilesURL, subStructure.FilesURL...)
-				structure.FilesSize = append(structure.FilesSize, subStructure.FilesSize...)
-				structure.Folders = append(structure.Folders, subStructure.Folders...)
-				structure.DownloadURLs = append(structure.DownloadURLs, subStructure.DownloadURLs...)
-				structure.FilesRequest = append(structure.FilesRequest, subStructure.FilesRequest...)
-			}
+	entries, err := listGitTree(owner, repo, ref, treeSha, path, token, args.Exclude)
+	if err != nil {
+		if err == provider.ErrPathNotFound {
+			return structure, provider.ErrPathNotFound
 		}
+		return structure, fmt.Errorf("failed to fetch tree for path %s: %v", path, err)
 	}
 
-	return structure, nil
+	return provider.BuildStructure(entries, path, args), nil
 }
 
 // fetchSingleFile fetches details for a single file from GitHub API
@@ -182,15 +90,14 @@ func fetchSingleFile(owner, repo, ref, path, token string) (types.GitHubContent,
 		req.Header.Add("Authorization", "token "+token)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := utils.Client.Do(req)
 	if err != nil {
 		return content, fmt.Errorf("failed to fetch file: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return content, ErrPathNotFound
+		return content, provider.ErrPathNotFound
 	} else if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
 		return content, fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
@@ -204,7 +111,7 @@ func fetchSingleFile(owner, repo, ref, path, token string) (types.GitHubContent,
 
 	// Check if response starts with '[' (indicating an array, i.e., directory)
 	if len(body) > 0 && body[0] == '[' {
-		return content, ErrPathNotFound // Treat as directory, not file
+		return content, provider.ErrPathNotFound // Treat as directory, not file
 	}
 
 	// Decode as single file
@@ -214,13 +121,3 @@ func fetchSingleFile(owner, repo, ref, path, token string) (types.GitHubContent,
 
 	return content, nil
 }
-
-// contains checks if a string slice contains a specific item
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
\ No newline at end of file
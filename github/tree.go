@@ -0,0 +1,168 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// gitTree represents a response of the Git Trees API
+type gitTree struct {
+	Sha       string        `json:"sha"`
+	Tree      []gitTreeItem `json:"tree"`
+	Truncated bool          `json:"truncated"`
+}
+
+// gitTreeItem represents an entry of a Git tree
+type gitTreeItem struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"` // blob, tree or commit (submodule)
+	Sha  string `json:"sha"`
+	Size int    `json:"size"`
+	URL  string `json:"url"`
+}
+
+// fetchGitTree fetches a tree by SHA or ref name, optionally with all nested entries
+func fetchGitTree(owner, repo, treeSha, token string, recursive bool) (gitTree, error) {
+	var tree gitTree
+	api := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s", owner, repo, url.PathEscape(treeSha))
+	if recursive {
+		api += "?recursive=1"
+	}
+	req, err := http.NewRequest("GET", api, nil)
+	if err != nil {
+		return tree, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Add("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Add("Authorization", "token "+token)
+	}
+
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return tree, fmt.Errorf("failed to fetch tree: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return tree, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return tree, fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return tree, fmt.Errorf("failed to decode tree: %v", err)
+	}
+
+	return tree, nil
+}
+
+// resolveTreeSha resolves the tree of path at ref. The root tree is addressed by
+// the ref itself; any other directory is looked up in its parent's contents.
+func resolveTreeSha(owner, repo, ref, dirPath, token string) (string, error) {
+	if dirPath == "" {
+		return ref, nil
+	}
+
+	parentPath, name := path.Split(dirPath)
+	contents, err := FetchGitHubContents(owner, repo, ref, strings.TrimSuffix(parentPath, "/"), token)
+	if err != nil {
+		return "", err
+	}
+	for _, content := range contents {
+		if content.Name == name && content.Type == "dir" {
+			return content.Sha, nil
+		}
+	}
+
+	return "", provider.ErrPathNotFound
+}
+
+// listGitTree lists every file below a tree, prefixing paths with prefix. When GitHub
+// truncates the recursive listing, each subtree is walked with its own request, except
+// for folders matching an exclude pattern.
+func listGitTree(owner, repo, ref, treeSha, prefix, token string, exclude []string) ([]types.TreeEntry, error) {
+	tree, err := fetchGitTree(owner, repo, treeSha, token, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.TreeEntry
+	if !tree.Truncated {
+		for _, item := range tree.Tree {
+			if item.Type == "blob" {
+				entries = append(entries, newTreeEntry(owner, repo, ref, prefix, item))
+			}
+		}
+		return entries, nil
+	}
+
+	// Fall back to the direct children and recurse into each subtree
+	tree, err = fetchGitTree(owner, repo, treeSha, token, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range tree.Tree {
+		switch item.Type {
+		case "blob":
+			entries = append(entries, newTreeEntry(owner, repo, ref, prefix, item))
+		case "tree":
+			subPath := joinPath(prefix, item.Path)
+			if utils.ExcludedPath(subPath, exclude, true) {
+				continue
+			}
+			subEntries, err := listGitTree(owner, repo, ref, item.Sha, subPath, token, exclude)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, subEntries...)
+		}
+	}
+
+	return entries, nil
+}
+
+// newTreeEntry converts a Git tree blob into a provider tree entry
+func newTreeEntry(owner, repo, ref, prefix string, item gitTreeItem) types.TreeEntry {
+	itemPath := joinPath(prefix, item.Path)
+	escapedPath := escapePath(itemPath)
+	return types.TreeEntry{
+		Name:        path.Base(itemPath),
+		Path:        itemPath,
+		Type:        "file",
+		Size:        item.Size,
+		Sha:         item.Sha,
+		URL:         fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s", owner, repo, escapedPath, url.QueryEscape(ref)),
+		HTMLURL:     fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", owner, repo, escapePath(ref), escapedPath),
+		GitURL:      item.URL,
+		DownloadURL: fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, escapePath(ref), escapedPath),
+	}
+}
+
+// joinPath joins a prefix and a relative path with a slash when the prefix is set
+func joinPath(prefix, itemPath string) string {
+	if prefix == "" {
+		return itemPath
+	}
+	return prefix + "/" + itemPath
+}
+
+// escapePath escapes each segment of a repository path for use in a URL
+func escapePath(itemPath string) string {
+	segments := strings.Split(itemPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
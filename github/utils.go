@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/NeerajCodz/dgf/provider"
 	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
 )
 
-// FetchGitHubContents fetches directory contents from GitHub API
+// FetchGitHubContents fetches directory contents from GitHub API, or the file itself
+// when path is a file
 func FetchGitHubContents(owner, repo, ref, path, token string) ([]types.GitHubContent, error) {
 	// Normalize repository name for API (case-insensitive)
 	owner = strings.ToLower(owner)
@@ -29,25 +32,38 @@ func FetchGitHubContents(owner, repo, ref, path, token string) ([]types.GitHubCo
 
 	req.Header.Add("Accept", "application/vnd.github+json")
 	if token != "" {
-		req.Header.Add("Authorization", "token " + token)
+		req.Header.Add("Authorization", "token "+token)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := utils.Client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch contents: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, ErrPathNotFound
+		return nil, provider.ErrPathNotFound
 	} else if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	// A file path returns the file itself as an object rather than an array
+	if len(body) > 0 && body[0] == '{' {
+		var content types.GitHubContent
+		if err := json.Unmarshal(body, &content); err != nil {
+			return nil, fmt.Errorf("failed to decode contents: %v", err)
+		}
+		return []types.GitHubContent{content}, nil
+	}
+
 	var contents []types.GitHubContent
-	if err := json.NewDecoder(resp.Body).Decode(&contents); err != nil {
+	if err := json.Unmarshal(body, &contents); err != nil {
 		return nil, fmt.Errorf("failed to decode contents: %v", err)
 	}
 
@@ -74,7 +90,7 @@ func getRequestType(url string, owner, repo, ref, parentPath, requestPath, token
 	if parentPath != "" {
 		contents, err := FetchGitHubContents(owner, repo, ref, parentPath, token)
 		if err != nil {
-			if err == ErrPathNotFound {
+			if err == provider.ErrPathNotFound {
 				return "", fmt.Errorf("parent path %s not found", parentPath)
 			}
 			return "", fmt.Errorf("failed to fetch parent path %s: %v", parentPath, err)
@@ -87,11 +103,13 @@ func getRequestType(url string, owner, repo, ref, parentPath, requestPath, token
 		return "", fmt.Errorf("request path %s not found in parent path %s", requestPath, parentPath)
 	}
 
-	// If no parentPath, check if fullPath is a directory
+	// If no parentPath, check if fullPath is a file or a directory
 	contents, err := FetchGitHubContents(owner, repo, ref, fullPath, token)
-	if err == nil && len(contents) > 0 {
+	if err == nil && len(contents) == 1 && contents[0].Type == "file" && contents[0].Path == fullPath {
+		return "file", nil
+	} else if err == nil && len(contents) > 0 {
 		return "dir", nil
-	} else if err != nil && err != ErrPathNotFound {
+	} else if err != nil && err != provider.ErrPathNotFound {
 		return "", fmt.Errorf("failed to fetch directory contents for path %s: %v", fullPath, err)
 	}
 
@@ -99,9 +117,9 @@ func getRequestType(url string, owner, repo, ref, parentPath, requestPath, token
 	content, err := fetchSingleFile(owner, repo, ref, fullPath, token)
 	if err == nil && content.Type == "file" {
 		return "file", nil
-	} else if err != ErrPathNotFound {
+	} else if err != provider.ErrPathNotFound {
 		return "", fmt.Errorf("failed to fetch file details for path %s: %v", fullPath, err)
 	}
 
-	return "", ErrPathNotFound
-}
\ No newline at end of file
+	return "", provider.ErrPathNotFound
+}
@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// ParseGitLabURL parses a GitLab URL or constructs one from site arguments.
+// Namespaces may contain subgroups, so everything before the project name
+// (e.g. group/subgroup) is stored as the username.
+func ParseGitLabURL(rawURL string, platform types.Platform, args types.Args) (types.ParsedURL, error) {
+	result := types.ParsedURL{
+		URL:  rawURL,
+		Name: platform.Name,
+		ID:   platform.ID,
+	}
+
+	// If site args are provided, construct the URL
+	if args.Username != "" || args.Repo != "" {
+		if args.Site == "" || args.Username == "" || args.Repo == "" {
+			return result, fmt.Errorf("must provide all of --site, --username, and --repo")
+		}
+		if strings.ToLower(args.Site) != platform.ID {
+			return result, fmt.Errorf("invalid site ID '%s'", args.Site)
+		}
+
+		result.URL = platform.URLStruc.Site
+		result.URL = strings.ReplaceAll(result.URL, "<username>", strings.Trim(args.Username, "/"))
+		result.URL = strings.ReplaceAll(result.URL, "<repo>", args.Repo)
+		result.Host = platform.URL.Site[0]
+		result.Username = strings.Trim(args.Username, "/")
+		result.Repo = args.Repo
+		return result, nil
+	}
+
+	// Accept bare host URLs such as gitlab.com/group/project
+	normalizedURL := rawURL
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		normalizedURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(normalizedURL)
+	if err != nil || u.Host == "" {
+		return result, fmt.Errorf("invalid URL format: %s", rawURL)
+	}
+	result.Host = u.Scheme + "://" + u.Host
+
+	// Split the namespace/project part from the /-/ route part
+	path := strings.Trim(u.Path, "/")
+	var route string
+	if idx := strings.Index(path, "/-/"); idx >= 0 {
+		route = path[idx+len("/-/"):]
+		path = path[:idx]
+	}
+	path = strings.TrimSuffix(path, ".git")
+
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return result, fmt.Errorf("invalid GitLab URL structure: missing namespace or project")
+	}
+	result.Username = strings.Join(segments[:len(segments)-1], "/")
+	result.Repo = segments[len(segments)-1]
+
+	// Parse branch or commit and path from tree/blob routes
+	routeSegments := strings.Split(route, "/")
+	if len(routeSegments) >= 2 && (routeSegments[0] == "tree" || routeSegments[0] == "blob") {
+		if utils.IsPotentialCommitHash(routeSegments[1], 7) {
+			result.Commit = routeSegments[1]
+		} else {
+			result.Branch = routeSegments[1]
+			if len(routeSegments) > 2 {
+				// The branch may contain slashes; the provider splits it off later
+				result.RefPath = strings.Join(routeSegments[1:], "/")
+			}
+		}
+		if len(routeSegments) > 2 {
+			provider.SetPath(&result, strings.Join(routeSegments[2:], "/"))
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+)
+
+func init() {
+	provider.Register("gitlab", New)
+}
+
+// Provider fetches repositories hosted on gitlab.com or self-hosted GitLab instances
+type Provider struct {
+	platform types.Platform
+	token    string
+}
+
+// New creates a GitLab provider, falling back to GITLAB_TOKEN when no token is given
+func New(platform types.Platform, token string) provider.Provider {
+	if token == "" {
+		token = os.Getenv("GITLAB_TOKEN")
+	}
+	return &Provider{platform: platform, token: token}
+}
+
+// ParseURL parses a GitLab URL or constructs one from site arguments
+func (p *Provider) ParseURL(url string, args types.Args) (types.ParsedURL, error) {
+	return ParseGitLabURL(url, p.platform, args)
+}
+
+// WebURL builds the GitLab tree URL for the parsed path at ref
+func (p *Provider) WebURL(parsed types.ParsedURL, ref string) string {
+	webURL := fmt.Sprintf("%s/%s/%s/-/tree/%s", parsed.Host, parsed.Username, parsed.Repo, ref)
+	if parsed.Path != "" {
+		webURL += "/" + parsed.Path
+	}
+	return webURL
+}
+
+// DefaultBranch retrieves the default branch of the project
+func (p *Provider) DefaultBranch(parsed types.ParsedURL) (string, error) {
+	return fetchDefaultBranch(parsed, p.token)
+}
+
+// ResolveCommit resolves ref to its full commit SHA
+func (p *Provider) ResolveCommit(parsed types.ParsedURL, ref string) (string, error) {
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if _, err := fetchJSON(projectAPI(parsed)+"/repository/commits/"+url.PathEscape(ref), p.token, &commit); err != nil {
+		if err == provider.ErrPathNotFound {
+			return "", fmt.Errorf("ref %s not found in %s/%s", ref, parsed.Username, parsed.Repo)
+		}
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+// ListTags returns the names of all tags in the project
+func (p *Provider) ListTags(parsed types.ParsedURL) ([]string, error) {
+	return fetchRefNames(parsed, "tags", "", p.token)
+}
+
+// MatchRefs returns the names of the branches and tags that start with prefix
+func (p *Provider) MatchRefs(parsed types.ParsedURL, prefix string) ([]string, []string, error) {
+	branches, err := fetchRefNames(parsed, "branches", prefix, p.token)
+	if err != nil {
+		return nil, nil, err
+	}
+	tags, err := fetchRefNames(parsed, "tags", prefix, p.token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return branches, tags, nil
+}
+
+// StatPath determines whether the parsed path is a file or directory
+func (p *Provider) StatPath(parsed types.ParsedURL, ref string) (string, error) {
+	// A file answers the files API; anything else must be a non-empty tree
+	_, _, err := fetchFileInfo(parsed, ref, parsed.Path, p.token)
+	if err == nil {
+		return "file", nil
+	} else if err != provider.ErrPathNotFound {
+		return "", fmt.Errorf("failed to fetch file details for path %s: %v", parsed.Path, err)
+	}
+
+	items, err := fetchTree(parsed, ref, parsed.Path, p.token, false)
+	if err != nil && err != provider.ErrPathNotFound {
+		return "", fmt.Errorf("failed to fetch directory contents for path %s: %v", parsed.Path, err)
+	}
+	if len(items) > 0 {
+		return "dir", nil
+	}
+
+	return "", provider.ErrPathNotFound
+}
+
+// ListTree fetches the repository structure below the parsed path
+func (p *Provider) ListTree(parsed types.ParsedURL, ref string, args types.Args) (types.RepositoryStructure, error) {
+	return FetchGitLabStructure(parsed, ref, p.token, args)
+}
+
+// OpenFile opens a download stream for a raw file URL, resuming at offset
+func (p *Provider) OpenFile(downloadURL string, offset int64) (*provider.FileStream, error) {
+	req, err := newRequest("GET", downloadURL, p.token)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenStream(req, offset)
+}
+
+// OpenPrefix opens the first length bytes of a file listed in DownloadURLs
+func (p *Provider) OpenPrefix(downloadURL string, length int64) (io.ReadCloser, error) {
+	req, err := newRequest("GET", downloadURL, p.token)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenPrefix(req, length)
+}
+
+// OpenTarball opens the .tar.gz archive of the project at ref, limited to the parsed path
+func (p *Provider) OpenTarball(parsed types.ParsedURL, ref string) (io.ReadCloser, error) {
+	api := fmt.Sprintf("%s/repository/archive.tar.gz?sha=%s", projectAPI(parsed), url.QueryEscape(ref))
+	if parsed.Path != "" {
+		api += "&path=" + url.QueryEscape(parsed.Path)
+	}
+	resp, err := doRequest("GET", api, p.token)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 404 {
+		resp.Body.Close()
+		return nil, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,118 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// FetchGitLabStructure fetches the repository structure, filtering files by format if specified
+func FetchGitLabStructure(parsed types.ParsedURL, ref, token string, args types.Args) (types.RepositoryStructure, error) {
+	// Handle single file request
+	if parsed.RequestType == "file" && parsed.Path != "" {
+		structure := provider.NewStructure()
+		if !provider.MatchFile(parsed.Path, args) {
+			return structure, nil
+		}
+
+		sha, size, err := fetchFileInfo(parsed, ref, parsed.Path, token)
+		if err != nil {
+			if err == provider.ErrPathNotFound {
+				return structure, provider.ErrPathNotFound
+			}
+			return structure, fmt.Errorf("failed to fetch file details for %s: %v", parsed.Path, err)
+		}
+
+		entry := newTreeEntry(parsed, ref, gitLabTreeItem{ID: sha, Name: parsed.RequestPath, Type: "blob", Path: parsed.Path})
+		entry.Size = size
+		if !provider.MatchSize(int64(size), args) {
+			return structure, nil
+		}
+		provider.AddFile(&structure, entry, parsed.RequestPath)
+		return structure, nil
+	}
+
+	// List the whole subtree in one paginated recursive call
+	items, err := fetchTree(parsed, ref, parsed.Path, token, true)
+	if err != nil {
+		if err == provider.ErrPathNotFound {
+			return provider.NewStructure(), provider.ErrPathNotFound
+		}
+		return provider.NewStructure(), fmt.Errorf("failed to fetch contents for path %s: %v", parsed.Path, err)
+	}
+
+	entries := make([]types.TreeEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, newTreeEntry(parsed, ref, item))
+	}
+	// The tree API has no sizes, so look them up only when the size options need them
+	if args.MinSize > 0 || args.MaxSize > 0 || args.MaxTotal > 0 {
+		if err := fillSizes(parsed, ref, token, entries, args); err != nil {
+			return provider.NewStructure(), err
+		}
+	}
+
+	return provider.BuildStructure(entries, parsed.Path, args), nil
+}
+
+// fillSizes looks up the size of every file that passes the filters, since the tree
+// API does not report sizes. Lookups run in parallel like downloads.
+func fillSizes(parsed types.ParsedURL, ref, token string, entries []types.TreeEntry, args types.Args) error {
+	jobs := args.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	sizeErrors := make([]error, len(entries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				_, entries[i].Size, sizeErrors[i] = fetchFileInfo(parsed, ref, entries[i].Path, token)
+			}
+		}()
+	}
+	for i, entry := range entries {
+		if entry.Type == "file" && provider.MatchFile(entry.Path, args) {
+			indexes <- i
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, err := range sizeErrors {
+		if err != nil {
+			return fmt.Errorf("failed to fetch file details for %s: %v", entries[i].Path, err)
+		}
+	}
+	return nil
+}
+
+// newTreeEntry converts a GitLab tree item into a provider tree entry.
+// The tree API does not report sizes, so Size is left at zero unless fillSizes runs;
+// downloads then check the size the server reports instead.
+func newTreeEntry(parsed types.ParsedURL, ref string, item gitLabTreeItem) types.TreeEntry {
+	entry := types.TreeEntry{
+		Name:    item.Name,
+		Path:    item.Path,
+		Type:    "file",
+		Sha:     item.ID,
+		HTMLURL: fmt.Sprintf("%s/%s/%s/-/blob/%s/%s", parsed.Host, parsed.Username, parsed.Repo, ref, item.Path),
+	}
+	if item.Type == "tree" {
+		entry.Type = "dir"
+		entry.HTMLURL = fmt.Sprintf("%s/%s/%s/-/tree/%s/%s", parsed.Host, parsed.Username, parsed.Repo, ref, item.Path)
+		return entry
+	}
+
+	filesAPI := fmt.Sprintf("%s/repository/files/%s", projectAPI(parsed), url.PathEscape(item.Path))
+	entry.URL = filesAPI + "?ref=" + url.QueryEscape(ref)
+	entry.GitURL = fmt.Sprintf("%s/repository/blobs/%s", projectAPI(parsed), item.ID)
+	entry.DownloadURL = filesAPI + "/raw?ref=" + url.QueryEscape(ref)
+	return entry
+}
@@ -0,0 +1,168 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// gitLabTreeItem represents an item returned by the repository tree API
+type gitLabTreeItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // tree or blob
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+// projectAPI returns the v4 API base URL of the parsed project
+func projectAPI(parsed types.ParsedURL) string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", parsed.Host, url.PathEscape(parsed.Username+"/"+parsed.Repo))
+}
+
+// newRequest creates a request authenticated with token when one is set
+func newRequest(method, api, token string) (*http.Request, error) {
+	req, err := http.NewRequest(method, api, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if token != "" {
+		req.Header.Add("PRIVATE-TOKEN", token)
+	}
+
+	return req, nil
+}
+
+// doRequest sends an authenticated request
+func doRequest(method, api, token string) (*http.Response, error) {
+	req, err := newRequest(method, api, token)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Client.Do(req)
+}
+
+// fetchJSON fetches an API endpoint and decodes the JSON response into v
+func fetchJSON(api, token string, v interface{}) (http.Header, error) {
+	resp, err := doRequest("GET", api, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", api, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return resp.Header, nil
+}
+
+// fetchDefaultBranch retrieves the default branch of a GitLab project
+func fetchDefaultBranch(parsed types.ParsedURL, token string) (string, error) {
+	var projectInfo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if _, err := fetchJSON(projectAPI(parsed), token, &projectInfo); err != nil {
+		if err == provider.ErrPathNotFound {
+			return "", fmt.Errorf("project %s/%s not found - check namespace, project, or token permissions", parsed.Username, parsed.Repo)
+		}
+		return "", err
+	}
+
+	if projectInfo.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found for %s/%s", parsed.Username, parsed.Repo)
+	}
+
+	return projectInfo.DefaultBranch, nil
+}
+
+// fetchTree lists the repository tree below path, following pagination
+func fetchTree(parsed types.ParsedURL, ref, path, token string, recursive bool) ([]gitLabTreeItem, error) {
+	var items []gitLabTreeItem
+	page := "1"
+	for page != "" {
+		query := url.Values{}
+		query.Set("ref", ref)
+		query.Set("per_page", "100")
+		query.Set("page", page)
+		if path != "" {
+			query.Set("path", path)
+		}
+		if recursive {
+			query.Set("recursive", "true")
+		}
+
+		var pageItems []gitLabTreeItem
+		header, err := fetchJSON(projectAPI(parsed)+"/repository/tree?"+query.Encode(), token, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		page = header.Get("X-Next-Page")
+	}
+
+	return items, nil
+}
+
+// fetchRefNames lists the names of the branches or tags of the project, following
+// pagination. A non-empty prefix limits the result to names starting with it.
+func fetchRefNames(parsed types.ParsedURL, kind, prefix, token string) ([]string, error) {
+	var names []string
+	page := "1"
+	for page != "" {
+		query := url.Values{}
+		query.Set("per_page", "100")
+		query.Set("page", page)
+		if prefix != "" {
+			query.Set("search", "^"+prefix)
+		}
+
+		var refs []struct {
+			Name string `json:"name"`
+		}
+		header, err := fetchJSON(projectAPI(parsed)+"/repository/"+kind+"?"+query.Encode(), token, &refs)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			names = append(names, ref.Name)
+		}
+		page = header.Get("X-Next-Page")
+	}
+
+	return names, nil
+}
+
+// fetchFileInfo retrieves the blob ID and size of a single file via a HEAD request
+func fetchFileInfo(parsed types.ParsedURL, ref, path, token string) (string, int, error) {
+	api := fmt.Sprintf("%s/repository/files/%s?ref=%s", projectAPI(parsed), url.PathEscape(path), url.QueryEscape(ref))
+	resp, err := doRequest("HEAD", api, token)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fetch file: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return "", 0, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		return "", 0, fmt.Errorf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	var size int
+	fmt.Sscanf(resp.Header.Get("X-Gitlab-Size"), "%d", &size)
+	return resp.Header.Get("X-Gitlab-Blob-Id"), size, nil
+}
@@ -0,0 +1,102 @@
+package huggingface
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// repoTypePrefixes maps URL prefixes to HuggingFace repository types
+var repoTypePrefixes = map[string]string{
+	"datasets": "dataset",
+	"spaces":   "space",
+}
+
+// ParseHuggingFaceURL parses a HuggingFace Hub URL or constructs one from site arguments.
+// Models live at the root (huggingface.co/<org>/<model>), datasets and spaces under
+// the /datasets/ and /spaces/ prefixes.
+func ParseHuggingFaceURL(rawURL string, platform types.Platform, args types.Args) (types.ParsedURL, error) {
+	result := types.ParsedURL{
+		URL:      rawURL,
+		Name:     platform.Name,
+		ID:       platform.ID,
+		RepoType: "model",
+	}
+
+	// If site args are provided, construct the URL of a model repository
+	if args.Username != "" || args.Repo != "" {
+		if args.Site == "" || args.Username == "" || args.Repo == "" {
+			return result, fmt.Errorf("must provide all of --site, --username, and --repo")
+		}
+		if strings.ToLower(args.Site) != platform.ID {
+			return result, fmt.Errorf("invalid site ID '%s'", args.Site)
+		}
+
+		result.URL = platform.URLStruc.Site
+		result.URL = strings.ReplaceAll(result.URL, "<username>", args.Username)
+		result.URL = strings.ReplaceAll(result.URL, "<repo>", args.Repo)
+		result.Host = platform.URL.Site[0]
+		result.Username = args.Username
+		result.Repo = args.Repo
+		return result, nil
+	}
+
+	// Accept bare host URLs such as huggingface.co/org/model
+	normalizedURL := rawURL
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		normalizedURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(normalizedURL)
+	if err != nil || u.Host == "" {
+		return result, fmt.Errorf("invalid URL format: %s", rawURL)
+	}
+	result.Host = u.Scheme + "://" + u.Host
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if repoType, exists := repoTypePrefixes[segments[0]]; exists {
+		result.RepoType = repoType
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return result, fmt.Errorf("invalid HuggingFace URL structure: missing repository")
+	}
+
+	// Canonical models such as huggingface.co/gpt2 have no organization
+	if len(segments) == 1 || isRoute(segments[1]) {
+		result.Repo = segments[0]
+		segments = segments[1:]
+	} else {
+		result.Username = segments[0]
+		result.Repo = segments[1]
+		segments = segments[2:]
+	}
+
+	// Parse revision and path from tree/blob/resolve routes
+	if len(segments) >= 2 && isRoute(segments[0]) {
+		revision, err := url.PathUnescape(segments[1])
+		if err != nil {
+			return result, fmt.Errorf("invalid revision '%s': %v", segments[1], err)
+		}
+		// Only full hashes count, since revisions are often short hex-like tags
+		if utils.IsPotentialCommitHash(revision, 40) {
+			result.Commit = revision
+		} else {
+			result.Branch = revision
+		}
+		if len(segments) > 2 {
+			provider.SetPath(&result, strings.Join(segments[2:], "/"))
+		}
+	}
+
+	return result, nil
+}
+
+// isRoute reports whether a URL segment selects a revision view
+func isRoute(segment string) bool {
+	return segment == "tree" || segment == "blob" || segment == "resolve"
+}
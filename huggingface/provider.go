@@ -0,0 +1,131 @@
+package huggingface
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+)
+
+func init() {
+	provider.Register("huggingface", New)
+}
+
+// Provider fetches model, dataset and space repositories from the HuggingFace Hub
+type Provider struct {
+	platform types.Platform
+	token    string
+}
+
+// New creates a HuggingFace provider, falling back to HF_TOKEN when no token is given
+func New(platform types.Platform, token string) provider.Provider {
+	if token == "" {
+		token = os.Getenv("HF_TOKEN")
+	}
+	return &Provider{platform: platform, token: token}
+}
+
+// ParseURL parses a HuggingFace URL or constructs one from site arguments
+func (p *Provider) ParseURL(url string, args types.Args) (types.ParsedURL, error) {
+	return ParseHuggingFaceURL(url, p.platform, args)
+}
+
+// WebURL builds the Hub tree URL for the parsed path at revision
+func (p *Provider) WebURL(parsed types.ParsedURL, revision string) string {
+	webURL := fmt.Sprintf("%s/tree/%s", repoURL(parsed), url.PathEscape(revision))
+	if parsed.Path != "" {
+		webURL += "/" + escapePath(parsed.Path)
+	}
+	return webURL
+}
+
+// DefaultBranch checks that the repository exists and returns its main branch.
+// Hub repositories always use "main" as the default revision.
+func (p *Provider) DefaultBranch(parsed types.ParsedURL) (string, error) {
+	var repoInfo struct {
+		ID string `json:"id"`
+	}
+	if _, err := fetchJSON(repoAPI(parsed), p.token, &repoInfo); err != nil {
+		if err == provider.ErrPathNotFound {
+			return "", fmt.Errorf("%s %s not found - check the repository name or token permissions", parsed.RepoType, repoID(parsed))
+		}
+		return "", err
+	}
+	return "main", nil
+}
+
+// ResolveCommit resolves a revision to its full commit SHA
+func (p *Provider) ResolveCommit(parsed types.ParsedURL, revision string) (string, error) {
+	var revisionInfo struct {
+		Sha string `json:"sha"`
+	}
+	if _, err := fetchJSON(repoAPI(parsed)+"/revision/"+url.PathEscape(revision), p.token, &revisionInfo); err != nil {
+		if err == provider.ErrPathNotFound {
+			return "", fmt.Errorf("revision %s not found in %s", revision, repoID(parsed))
+		}
+		return "", err
+	}
+	return revisionInfo.Sha, nil
+}
+
+// ListTags returns the names of all tags in the repository
+func (p *Provider) ListTags(parsed types.ParsedURL) ([]string, error) {
+	var refs struct {
+		Tags []struct {
+			Name string `json:"name"`
+		} `json:"tags"`
+	}
+	if _, err := fetchJSON(repoAPI(parsed)+"/refs", p.token, &refs); err != nil {
+		if err == provider.ErrPathNotFound {
+			return nil, fmt.Errorf("%s %s not found - check the repository name or token permissions", parsed.RepoType, repoID(parsed))
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(refs.Tags))
+	for _, tag := range refs.Tags {
+		names = append(names, tag.Name)
+	}
+	return names, nil
+}
+
+// StatPath determines whether the parsed path is a file or directory
+func (p *Provider) StatPath(parsed types.ParsedURL, revision string) (string, error) {
+	item, err := findItem(parsed, revision, p.token)
+	if err != nil {
+		if err == provider.ErrPathNotFound {
+			return "", provider.ErrPathNotFound
+		}
+		return "", fmt.Errorf("failed to fetch contents of %s: %v", parsed.ParentPath, err)
+	}
+	if item.Type == "directory" {
+		return "dir", nil
+	}
+	return "file", nil
+}
+
+// ListTree fetches the repository structure below the parsed path
+func (p *Provider) ListTree(parsed types.ParsedURL, revision string, args types.Args) (types.RepositoryStructure, error) {
+	return FetchHuggingFaceStructure(parsed, revision, p.token, args)
+}
+
+// OpenFile opens a download stream for a resolve URL, following LFS redirects and resuming at offset
+func (p *Provider) OpenFile(downloadURL string, offset int64) (*provider.FileStream, error) {
+	req, err := newRequest("GET", downloadURL, p.token)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenStream(req, offset)
+}
+
+// OpenPrefix opens the first length bytes of a file listed in DownloadURLs
+func (p *Provider) OpenPrefix(downloadURL string, length int64) (io.ReadCloser, error) {
+	req, err := newRequest("GET", downloadURL, p.token)
+	if err != nil {
+		return nil, err
+	}
+	return provider.OpenPrefix(req, length)
+}
@@ -0,0 +1,89 @@
+package huggingface
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// FetchHuggingFaceStructure fetches the repository structure, filtering files by format if specified
+func FetchHuggingFaceStructure(parsed types.ParsedURL, revision, token string, args types.Args) (types.RepositoryStructure, error) {
+	// Handle single file request by looking it up in its parent directory
+	if parsed.RequestType == "file" && parsed.Path != "" {
+		structure := provider.NewStructure()
+		if !provider.MatchFile(parsed.Path, args) {
+			return structure, nil
+		}
+
+		item, err := findItem(parsed, revision, token)
+		if err != nil {
+			return structure, err
+		}
+		entry := newTreeEntry(parsed, revision, item)
+		if !provider.MatchSize(int64(entry.Size), args) {
+			return structure, nil
+		}
+		provider.AddFile(&structure, entry, parsed.RequestPath)
+		return structure, nil
+	}
+
+	// List the whole subtree in one paginated recursive call
+	items, err := fetchTree(parsed, revision, parsed.Path, token, true)
+	if err != nil {
+		if err == provider.ErrPathNotFound {
+			return provider.NewStructure(), provider.ErrPathNotFound
+		}
+		return provider.NewStructure(), fmt.Errorf("failed to fetch contents for path %s: %v", parsed.Path, err)
+	}
+
+	entries := make([]types.TreeEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, newTreeEntry(parsed, revision, item))
+	}
+
+	return provider.BuildStructure(entries, parsed.Path, args), nil
+}
+
+// findItem looks up the parsed path in the listing of its parent directory
+func findItem(parsed types.ParsedURL, revision, token string) (hubTreeItem, error) {
+	items, err := fetchTree(parsed, revision, parsed.ParentPath, token, false)
+	if err != nil {
+		return hubTreeItem{}, err
+	}
+	for _, item := range items {
+		if item.Path == parsed.Path {
+			return item, nil
+		}
+	}
+	return hubTreeItem{}, provider.ErrPathNotFound
+}
+
+// newTreeEntry converts a Hub tree item into a provider tree entry
+func newTreeEntry(parsed types.ParsedURL, revision string, item hubTreeItem) types.TreeEntry {
+	entry := types.TreeEntry{
+		Name: path.Base(item.Path),
+		Path: item.Path,
+		Type: "file",
+		Size: item.Size,
+		Sha:  item.Oid,
+	}
+	if item.Type == "directory" {
+		entry.Type = "dir"
+		entry.HTMLURL = fmt.Sprintf("%s/tree/%s/%s", repoURL(parsed), url.PathEscape(revision), escapePath(item.Path))
+		return entry
+	}
+	if item.LFS != nil {
+		// The oid of an LFS file hashes its pointer, the LFS oid is the SHA-256 of the content
+		entry.Size = item.LFS.Size
+		entry.Sha = "sha256:" + item.LFS.Oid
+	}
+
+	entry.URL = fmt.Sprintf("%s/tree/%s/%s", repoAPI(parsed), url.PathEscape(revision), escapePath(item.Path))
+	entry.HTMLURL = fmt.Sprintf("%s/blob/%s/%s", repoURL(parsed), url.PathEscape(revision), escapePath(item.Path))
+	// The resolve endpoint redirects LFS files to their storage backend
+	entry.DownloadURL = fmt.Sprintf("%s/resolve/%s/%s", repoURL(parsed), url.PathEscape(revision), escapePath(item.Path))
+	return entry
+}
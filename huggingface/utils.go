@@ -0,0 +1,141 @@
+package huggingface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/provider"
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// hubTreeItem represents an item returned by the Hub tree API
+type hubTreeItem struct {
+	Type string `json:"type"` // file or directory
+	Oid  string `json:"oid"`
+	Size int    `json:"size"`
+	Path string `json:"path"`
+	LFS  *struct {
+		Oid         string `json:"oid"`
+		Size        int    `json:"size"`
+		PointerSize int    `json:"pointerSize"`
+	} `json:"lfs"`
+}
+
+// repoID returns the Hub repository ID (org/name or name)
+func repoID(parsed types.ParsedURL) string {
+	if parsed.Username == "" {
+		return parsed.Repo
+	}
+	return parsed.Username + "/" + parsed.Repo
+}
+
+// repoURL returns the web URL of the repository, including the datasets/ or spaces/ prefix
+func repoURL(parsed types.ParsedURL) string {
+	switch parsed.RepoType {
+	case "dataset":
+		return parsed.Host + "/datasets/" + repoID(parsed)
+	case "space":
+		return parsed.Host + "/spaces/" + repoID(parsed)
+	}
+	return parsed.Host + "/" + repoID(parsed)
+}
+
+// repoAPI returns the API URL of the repository
+func repoAPI(parsed types.ParsedURL) string {
+	return fmt.Sprintf("%s/api/%ss/%s", parsed.Host, parsed.RepoType, repoID(parsed))
+}
+
+// escapePath escapes each segment of a repository path for use in a URL
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// newRequest creates a request authenticated with token when one is set
+func newRequest(method, api, token string) (*http.Request, error) {
+	req, err := http.NewRequest(method, api, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if token != "" {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	return req, nil
+}
+
+// doRequest sends an authenticated request
+func doRequest(method, api, token string) (*http.Response, error) {
+	req, err := newRequest(method, api, token)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Client.Do(req)
+}
+
+// fetchJSON fetches an API endpoint and decodes the JSON response into v
+func fetchJSON(api, token string, v interface{}) (http.Header, error) {
+	resp, err := doRequest("GET", api, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", api, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, provider.ErrPathNotFound
+	} else if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return resp.Header, nil
+}
+
+// fetchTree lists the repository tree below path at revision, following Link pagination
+func fetchTree(parsed types.ParsedURL, revision, path, token string, recursive bool) ([]hubTreeItem, error) {
+	api := fmt.Sprintf("%s/tree/%s", repoAPI(parsed), url.PathEscape(revision))
+	if path != "" {
+		api += "/" + escapePath(path)
+	}
+	if recursive {
+		api += "?recursive=true"
+	}
+
+	var items []hubTreeItem
+	for api != "" {
+		var pageItems []hubTreeItem
+		header, err := fetchJSON(api, token, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		api = nextLink(header.Get("Link"))
+	}
+
+	return items, nil
+}
+
+// nextLink extracts the rel="next" URL from a Link header
+func nextLink(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 || strings.TrimSpace(sections[1]) != `rel="next"` {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(sections[0]), "<>")
+	}
+	return ""
+}
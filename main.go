@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
-	"github.com/NeerajCodz/dgf/github"
+	_ "github.com/NeerajCodz/dgf/github"
+	_ "github.com/NeerajCodz/dgf/gitlab"
+	_ "github.com/NeerajCodz/dgf/huggingface"
+	"github.com/NeerajCodz/dgf/provider"
 	"github.com/NeerajCodz/dgf/types"
 	"github.com/NeerajCodz/dgf/utils"
 )
@@ -20,6 +25,43 @@ func main() {
 	// Parse command-line arguments
 	args := ParseArgs()
 
+	// Configure retries of the shared HTTP client
+	if args.NoPrint {
+		utils.Transport.Log = nil
+	}
+	if args.WaitLimit {
+		utils.Transport.MaxWait = 24 * time.Hour
+	}
+
+	// dgf formats lists the effective format categories
+	if args.Command == "formats" {
+		formats, err := utils.LoadFormats(formatsData, args.Config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printFormats(formats)
+		return
+	}
+
+	// --verify without a URL re-checks a previous download against its lockfile
+	if args.VerifyDir != "" && args.URL == "" && args.Site == "" {
+		report, err := provider.VerifyLock(args.VerifyDir, args.DeleteMismatch)
+		if err != nil {
+			if !args.NoPrint {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		if !args.NoPrint {
+			report.Print()
+		}
+		if len(report.Mismatches) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Parse the embedded platforms configuration
 	var platforms []types.Platform
 	if err := json.Unmarshal(configData, &platforms); err != nil {
@@ -29,6 +71,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// dgf update replays the request recorded in the lockfile of a previous download
+	var lock types.Lock
+	if args.Command == "update" {
+		var err error
+		lock, err = provider.ReadLock(args.Output)
+		if err != nil {
+			if !args.NoPrint {
+				fmt.Fprintf(os.Stderr, "Error: no usable %s in %s: %v\n", provider.LockFileName, args.Output, err)
+			}
+			os.Exit(1)
+		}
+		args.Site = lock.Platform
+	}
+
 	// Determine the selected platform based on args.Site or args.URL
 	var selectedPlatform types.Platform
 	if args.Site != "" {
@@ -47,10 +103,12 @@ func main() {
 			os.Exit(1)
 		}
 	} else if args.URL != "" {
-		// Use URL to select platform
+		// Use URL to select platform, ignoring the scheme so bare hosts match too
+		bareURL := stripScheme(args.URL)
 		for _, p := range platforms {
-			for _, site := range p.URL.Site {
-				if strings.HasPrefix(args.URL, site) {
+			for _, site := range append(append([]string{}, p.URL.Site...), p.URL.Raw...) {
+				site = stripScheme(site)
+				if bareURL == site || strings.HasPrefix(bareURL, site+"/") {
 					selectedPlatform = p
 					break
 				}
@@ -59,6 +117,27 @@ func main() {
 				break
 			}
 		}
+		// Fall back to URL patterns to recognize self-hosted instances
+		for _, p := range platforms {
+			if selectedPlatform.ID != "" {
+				break
+			}
+			for _, pattern := range p.URL.Pattern {
+				if strings.Contains(args.URL, pattern) {
+					selectedPlatform = p
+					break
+				}
+			}
+		}
+		// owner/repo shorthand has no host at all
+		if selectedPlatform.ID == "" && !strings.Contains(args.URL, "://") && !strings.Contains(strings.SplitN(args.URL, "/", 2)[0], ".") {
+			for _, p := range platforms {
+				if p.URL.Shorthand {
+					selectedPlatform = p
+					break
+				}
+			}
+		}
 		if selectedPlatform.ID == "" {
 			if !args.NoPrint {
 				fmt.Fprintf(os.Stderr, "Error: URL does not match any configured platform\n")
@@ -72,59 +151,181 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Process GitHub-specific logic
-	if selectedPlatform.ID == "github" {
-		// Use args.URL if provided; otherwise, pass empty string to construct URL from site args
-		urlToUse := args.URL
-		if args.Site != "" {
-			urlToUse = ""
+	// Create the provider registered for the selected platform
+	p, err := provider.New(selectedPlatform, args.Token)
+	if err != nil {
+		if !args.NoPrint {
+			fmt.Fprintf(os.Stderr, "Error: Platform not supported\n")
+		}
+		os.Exit(1)
+	}
+
+	if args.Command == "update" {
+		if err := provider.Update(p, lock, args.Output, args); err != nil {
+			if !args.NoPrint {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Download the repository archive instead of listing the repository
+	if args.Tarball {
+		parsed, ref, err := provider.Resolve(p, args.URL, args)
+		if err == nil {
+			err = provider.DownloadTarball(p, parsed, ref, args.Output, args)
 		}
-		parsed, structure, err := github.ProcessGitHubURL(urlToUse, args.Token, args.Branch, args.Commit, args.Path, selectedPlatform, args)
-		if args.Check {
-			// Handle --check flag
+		if err != nil {
 			if !args.NoPrint {
-				if err == github.ErrPathNotFound {
-					fmt.Println(`{"exists": false}`)
-				} else if err == nil {
-					fmt.Println(`{"exists": true}`)
-				} else {
-					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				}
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	// args.URL is empty when the URL has to be constructed from site args
+	parsed, structure, err := provider.Process(p, args.URL, args)
+	if args.Check {
+		// Handle --check flag
+		if !args.NoPrint {
+			if err == provider.ErrPathNotFound {
+				fmt.Println(`{"exists": false}`)
+			} else if err == nil {
+				fmt.Println(`{"exists": true}`)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			}
+		}
+		return
+	}
+
+	// Stream a single file, or a line range of it, to stdout and nothing else
+	if args.Command == "cat" && err == nil {
+		if parsed.LineStart > 0 {
+			err = provider.ExtractLines(p, structure, parsed, "-", args)
 		} else {
-			// Handle normal operation
-			if err != nil {
+			err = provider.Cat(p, structure, parsed, os.Stdout)
+		}
+	}
+
+	// Handle normal operation
+	if err != nil {
+		if !args.NoPrint {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	if args.Command == "cat" {
+		return
+	}
+	if !args.NoPrint {
+		if args.PrintInfo {
+			// Print parsed info and structure as JSON
+			info := struct {
+				Parsed    types.ParsedURL           `json:"parsed"`
+				Structure types.RepositoryStructure `json:"structure"`
+			}{parsed, structure}
+			jsonData, _ := json.MarshalIndent(info, "", "  ")
+			fmt.Println(string(jsonData))
+		}
+		if args.PrintTree {
+			// Print directory tree
+			utils.TreePrint(structure)
+		}
+	}
+	// Re-check a previous download instead of downloading
+	if args.VerifyDir != "" {
+		report := provider.Verify(structure, args.VerifyDir, args.DeleteMismatch)
+		if !args.NoPrint {
+			report.Print()
+		}
+		if len(report.Mismatches) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Download files if no print flags are set
+	if !args.PrintTree && !args.PrintInfo && !args.Check {
+		// A #L anchor or --lines only extracts that range of the file
+		if parsed.LineStart > 0 {
+			if err := provider.ExtractLines(p, structure, parsed, args.Output, args); err != nil {
 				if !args.NoPrint {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				}
 				os.Exit(1)
 			}
-			if !args.NoPrint {
-				if args.PrintInfo {
-					// Print parsed info and structure as JSON
-					info := struct {
-						Parsed    types.ParsedURL           `json:"parsed"`
-						Structure types.RepositoryStructure `json:"structure"`
-					}{parsed, structure}
-					jsonData, _ := json.MarshalIndent(info, "", "  ")
-					fmt.Println(string(jsonData))
+			return
+		}
+		// Sync checks the budget against the files it actually downloads
+		if !args.Sync {
+			if err := provider.CheckBudget(structure, args); err != nil {
+				if !args.NoPrint {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				}
-				if args.PrintTree {
-					// Print directory tree
-					utils.TreePrint(structure)
+				os.Exit(1)
+			}
+		}
+		// Stream the files into an archive instead of the output directory
+		if args.Archive != "" {
+			if err := provider.WriteArchive(p, structure, args.Archive, args, parsed); err != nil {
+				if !args.NoPrint {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				}
+				os.Exit(1)
 			}
-			// Download files if no print flags are set
-			if !args.PrintTree && !args.PrintInfo && !args.Check {
-				github.Download(structure, args.Token, args.Output, args, parsed)
+			return
+		}
+		if args.Output == "-" {
+			if !args.NoPrint {
+				fmt.Fprintf(os.Stderr, "Error: --output - only applies to line ranges\n")
 			}
+			os.Exit(1)
+		}
+		if args.Sync {
+			err = provider.Sync(p, structure, args.Output, args, parsed)
+		} else {
+			err = provider.Download(p, structure, args.Output, args, parsed)
+		}
+		// Record provenance only when the output matches the listing
+		if err == nil {
+			err = provider.WriteLock(structure, args.Output, args, parsed)
+		}
+		if err != nil {
+			if !args.NoPrint {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
 		}
-		return
 	}
+}
 
-	// Handle unsupported platforms
-	if !args.NoPrint {
-		fmt.Fprintf(os.Stderr, "Error: Platform not supported\n")
+// stripScheme removes the http:// or https:// prefix of a URL
+func stripScheme(url string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+}
+
+// printFormats prints every format category with its extensions, naming the members
+// of composite categories
+func printFormats(formats utils.Formats) {
+	names := make([]string, 0, len(formats.Categories))
+	for name := range formats.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if formats.Source != "" {
+		fmt.Printf("Format categories (merged with %s):\n", formats.Source)
+	} else {
+		fmt.Println("Format categories:")
+	}
+	for _, name := range names {
+		label := name
+		if members, ok := formats.Composites[name]; ok {
+			label += " (" + strings.Join(members, " + ") + ")"
+		}
+		fmt.Printf("  %s: %s\n", label, strings.Join(formats.Categories[name], ", "))
 	}
-	os.Exit(1)
-}
\ No newline at end of file
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// ArchiveKind returns the archive format of an --archive path: tar, tar.gz or zip.
+// Writing to stdout ("-") produces tar.gz.
+func ArchiveKind(name string) (string, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case name == "-", strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz", nil
+	case strings.HasSuffix(lower, ".tar"):
+		return "tar", nil
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip", nil
+	case strings.HasSuffix(lower, ".zst"), strings.HasSuffix(lower, ".tzst"):
+		return "", fmt.Errorf("zstd compression is not supported; use .tar.gz, .tar or .zip")
+	}
+	return "", fmt.Errorf("unknown archive type for %s; use .tar.gz, .tgz, .tar, .zip or -", name)
+}
+
+// archiveTime is the modification time of every archive entry. It defaults to the
+// earliest time zip can store and follows SOURCE_DATE_EPOCH when that is set.
+func archiveTime() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// archiveWriter adds directories and files to an archive
+type archiveWriter interface {
+	addDir(name string) error
+	addFile(name string, size int64, r io.Reader) error // size is -1 when unknown
+	Close() error
+}
+
+// WriteArchive streams the files of structure into a tar, tar.gz or zip archive at
+// archivePath, or a tar.gz on stdout for "-", using the request paths as entry names.
+// Entries are written in path order with fixed metadata, so the same files always
+// produce the same archive.
+func WriteArchive(p Provider, structure types.RepositoryStructure, archivePath string, args types.Args, parsed types.ParsedURL) error {
+	kind, err := ArchiveKind(archivePath)
+	if err != nil {
+		return err
+	}
+
+	// Nothing but the archive may go to stdout
+	toStdout := archivePath == "-"
+	noPrint := args.NoPrint || toStdout
+
+	var out io.Writer = os.Stdout
+	if !toStdout {
+		file, err := os.Create(archivePath)
+		if err != nil {
+			return fmt.Errorf("failed to create archive %s: %v", archivePath, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if !noPrint {
+		fmt.Println()
+		fmt.Printf("Archiving %s Folders and files\n", parsed.ID)
+		fmt.Println()
+		fmt.Printf("REPO: %s/%s\n", parsed.Username, parsed.Repo)
+		fmt.Printf("PATH: %s\n", parsed.Path)
+		fmt.Printf("COMMIT: %s\n", parsed.CommitSha)
+		fmt.Printf("SIZE: %s\n", utils.FormatSize(structure.FilesSize))
+		fmt.Printf("OBJECTS: (%d files, %d folders)\n", len(structure.FilesRequest), len(structure.Folders))
+		if len(args.Formats) > 0 {
+			fmt.Printf("FORMATS: %v\n", args.Formats)
+		}
+		if len(args.ExcludeFormats) > 0 {
+			fmt.Printf("EXCLUDED FORMATS: %v\n", args.ExcludeFormats)
+		}
+		fmt.Printf("SAVED IN: %s\n", archivePath)
+		fmt.Println()
+	}
+
+	var archive archiveWriter
+	switch kind {
+	case "zip":
+		archive = &zipArchive{zip.NewWriter(out)}
+	case "tar":
+		archive = &tarArchive{writer: tar.NewWriter(out)}
+	default:
+		gzipWriter := gzip.NewWriter(out)
+		archive = &tarArchive{writer: tar.NewWriter(gzipWriter), closer: gzipWriter}
+	}
+
+	err = writeEntries(p, structure, archive, noPrint)
+	if closeErr := archive.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write archive %s: %v", archivePath, closeErr)
+	}
+	if err != nil {
+		if !toStdout {
+			os.Remove(archivePath)
+		}
+		return err
+	}
+
+	if !noPrint {
+		fmt.Println("DONE")
+	}
+	return nil
+}
+
+// writeEntries adds the folders and files of structure to the archive in path order
+func writeEntries(p Provider, structure types.RepositoryStructure, archive archiveWriter, noPrint bool) error {
+	// Every parent of a file gets an entry, including the requested folder itself
+	seen := make(map[string]bool)
+	var folders []string
+	for _, requestPath := range structure.FilesRequest {
+		for dir := path.Dir(requestPath); dir != "." && !seen[dir]; dir = path.Dir(dir) {
+			seen[dir] = true
+			folders = append(folders, dir)
+		}
+	}
+	sort.Strings(folders)
+	for _, folder := range folders {
+		if err := archive.addDir(folder); err != nil {
+			return fmt.Errorf("failed to add %s: %v", folder, err)
+		}
+	}
+
+	order := make([]int, len(structure.FilesRequest))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return structure.FilesRequest[order[a]] < structure.FilesRequest[order[b]] })
+
+	progress := newProgressBar(len(order), noPrint)
+	for _, i := range order {
+		if err := addRemoteFile(p, structure, i, archive); err != nil {
+			return err
+		}
+		progress.increment()
+	}
+	if len(order) > 0 && !noPrint {
+		progress.finish()
+	}
+	return nil
+}
+
+// addRemoteFile streams file i of structure into the archive
+func addRemoteFile(p Provider, structure types.RepositoryStructure, i int, archive archiveWriter) error {
+	if structure.DownloadURLs[i] == "" {
+		return fmt.Errorf("no download URL for file %s", structure.FilesRequest[i])
+	}
+	stream, err := p.OpenFile(structure.DownloadURLs[i], 0)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", structure.DownloadURLs[i], err)
+	}
+	defer stream.Close()
+
+	// Prefer the listed size, then the size reported by the server
+	size := int64(structure.FilesSize[i])
+	if size == 0 {
+		size = stream.Size
+	}
+	counter := &countingReader{reader: stream}
+	if err := archive.addFile(structure.FilesRequest[i], size, counter); err != nil {
+		return fmt.Errorf("failed to archive %s: %v", structure.FilesRequest[i], err)
+	}
+	if size >= 0 && counter.n != size {
+		return fmt.Errorf("incomplete download of %s: got %d of %d bytes", structure.FilesRequest[i], counter.n, size)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// tarArchive writes a tar stream, optionally wrapped in a compressor
+type tarArchive struct {
+	writer *tar.Writer
+	closer io.Closer
+}
+
+func (a *tarArchive) addDir(name string) error {
+	return a.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name + "/",
+		Mode:     0755,
+		ModTime:  archiveTime(),
+		Format:   tar.FormatPAX,
+	})
+}
+
+func (a *tarArchive) addFile(name string, size int64, r io.Reader) error {
+	// Tar headers need the size up front, so spool files of unknown size first
+	if size < 0 {
+		spool, err := os.CreateTemp("", "dgf-archive-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+		if size, err = io.Copy(spool, r); err != nil {
+			return err
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		r = spool
+	}
+
+	if err := a.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  archiveTime(),
+		Format:   tar.FormatPAX,
+	}); err != nil {
+		return err
+	}
+	_, err := io.Copy(a.writer, r)
+	return err
+}
+
+func (a *tarArchive) Close() error {
+	err := a.writer.Close()
+	if a.closer != nil {
+		if closeErr := a.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// zipArchive writes a deflate-compressed zip file
+type zipArchive struct {
+	writer *zip.Writer
+}
+
+func (a *zipArchive) addDir(name string) error {
+	header := &zip.FileHeader{Name: name + "/", Modified: archiveTime()}
+	header.SetMode(os.ModeDir | 0755)
+	_, err := a.writer.CreateHeader(header)
+	return err
+}
+
+func (a *zipArchive) addFile(name string, size int64, r io.Reader) error {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: archiveTime()}
+	header.SetMode(0644)
+	w, err := a.writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (a *zipArchive) Close() error {
+	return a.writer.Close()
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// CheckBudget returns an error when the files of structure add up to more than the
+// --max-total budget. On a terminal the user is asked whether to go ahead instead.
+func CheckBudget(structure types.RepositoryStructure, args types.Args) error {
+	var total int64
+	for _, size := range structure.FilesSize {
+		total += int64(size)
+	}
+	if args.MaxTotal == 0 || total <= args.MaxTotal {
+		return nil
+	}
+
+	over := fmt.Sprintf("%d files total %s, over the --max-total budget of %s", len(structure.Files),
+		utils.FormatSize(structure.FilesSize), utils.FormatSize([]int{int(args.MaxTotal)}))
+	if args.NoPrint || !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+		return fmt.Errorf("%s", over)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s. Download anyway? [y/N] ", over)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("download aborted: %s", over)
+}
+
+// isTerminal reports whether file is an interactive terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// Cat streams the single file in structure to w without any other output. Files are
+// read from their download URL, so there is no size limit.
+func Cat(p Provider, structure types.RepositoryStructure, parsed types.ParsedURL, w io.Writer) error {
+	switch {
+	case parsed.RequestType == "release" && len(structure.Files) != 1:
+		return fmt.Errorf("%d assets of release %s match; select one with --asset", len(structure.Files), parsed.Tag)
+	case parsed.RequestType != "file" && parsed.RequestType != "release":
+		name := parsed.Path
+		if name == "" {
+			name = parsed.Username + "/" + parsed.Repo
+		}
+		return fmt.Errorf("%s is a directory", name)
+	case len(structure.Files) != 1:
+		return fmt.Errorf("%s does not pass the file filters", parsed.Path)
+	}
+
+	stream, err := p.OpenFile(structure.DownloadURLs[0], 0)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", structure.DownloadURLs[0], err)
+	}
+	defer stream.Close()
+
+	written, err := io.Copy(w, stream)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", structure.Files[0], err)
+	}
+	if expected := int64(structure.FilesSize[0]); expected > 0 && written != expected {
+		return fmt.Errorf("incomplete download of %s: got %d of %d bytes", structure.Files[0], written, expected)
+	}
+	return nil
+}
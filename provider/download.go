@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// Download downloads files through the provider and creates directories in the specified output directory.
+// It returns an error if the output directory is unusable or any file failed to download or verify.
+func Download(p Provider, structure types.RepositoryStructure, outputDir string, args types.Args, parsed types.ParsedURL) error {
+	// Validate output directory
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %v", outputDir, err)
+	}
+	if info, err := os.Stat(outputDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", outputDir)
+	}
+
+	// Calculate total counts
+	totalFiles := len(structure.FilesRequest)
+	totalFolders := len(structure.Folders)
+
+	// Print header if NoPrint is false
+	if !args.NoPrint {
+		fmt.Println()
+		fmt.Printf("Downloading %s Folders and files\n", parsed.ID)
+		fmt.Println()
+		fmt.Printf("REPO: %s/%s\n", parsed.Username, parsed.Repo)
+		fmt.Printf("PATH: %s\n", parsed.Path)
+		if args.Commit != "" {
+			fmt.Printf("COMMIT: %s\n", args.Commit)
+		} else if parsed.Tag != "" {
+			fmt.Printf("TAG: %s\n", parsed.Tag)
+		} else if args.Branch != "" {
+			fmt.Printf("BRANCH: %s\n", args.Branch)
+		}
+		fmt.Printf("SIZE: %s\n", utils.FormatSize(structure.FilesSize))
+		fmt.Printf("OBJECTS: (%d files, %d folders)\n", totalFiles, totalFolders)
+		if len(args.Formats) > 0 {
+			fmt.Printf("FORMATS: %v\n", args.Formats)
+		}
+		if len(args.ExcludeFormats) > 0 {
+			fmt.Printf("EXCLUDED FORMATS: %v\n", args.ExcludeFormats)
+		}
+		fmt.Printf("SAVED IN: %s\n", outputDir)
+		fmt.Println()
+	}
+
+	// Create directories (only RequestPath)
+	var createdDirs []string
+	for _, folder := range structure.Folders {
+		dirPath := filepath.Join(outputDir, folder)
+		if err := os.MkdirAll(dirPath, 0755); err != nil {
+			if !args.NoPrint {
+				createdDirs = append(createdDirs, fmt.Sprintf("Error creating directory %s: %v", dirPath, err))
+			}
+			continue
+		}
+		if !args.NoPrint {
+			createdDirs = append(createdDirs, fmt.Sprintf("Created directory: %s", dirPath))
+		}
+	}
+
+	// Download files with a bounded pool of workers
+	jobs := args.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	progress := newProgressBar(totalFiles, args.NoPrint)
+	fileErrors := make([]error, totalFiles)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fileErrors[i] = downloadFile(p, structure.DownloadURLs[i], outputDir, structure.FilesRequest[i], structure.FilesSize[i], structure.FilesSha[i])
+				progress.increment()
+			}
+		}()
+	}
+	for i := range structure.DownloadURLs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// Collect per-file errors in listing order
+	var downloadMessages []string
+	for _, err := range fileErrors {
+		if err != nil {
+			downloadMessages = append(downloadMessages, err.Error())
+		}
+	}
+
+	// Verify downloaded files against their listed SHA
+	var verifyReport VerifyReport
+	if args.VerifySha {
+		downloaded := SelectFiles(structure, func(i int) bool { return fileErrors[i] == nil })
+		verifyReport = Verify(downloaded, outputDir, args.DeleteMismatch)
+	}
+
+	// Print final progress bar and messages if NoPrint is false
+	if !args.NoPrint {
+		if totalFiles > 0 {
+			progress.finish()
+			fmt.Println()
+		}
+		for _, msg := range createdDirs {
+			fmt.Println(msg)
+		}
+		if len(downloadMessages) > 0 {
+			fmt.Printf("FAILED: %d of %d files\n", len(downloadMessages), totalFiles)
+		}
+		for _, msg := range downloadMessages {
+			fmt.Println(msg)
+		}
+		if args.VerifySha {
+			verifyReport.Print()
+		}
+		fmt.Println("DONE")
+	}
+
+	if len(downloadMessages) > 0 {
+		return fmt.Errorf("%d of %d files failed to download", len(downloadMessages), totalFiles)
+	}
+	if len(verifyReport.Mismatches) > 0 {
+		return fmt.Errorf("%d files failed verification", len(verifyReport.Mismatches))
+	}
+	return nil
+}
+
+// downloadFile downloads a single file to its request path below outputDir. Data is
+// written to a .part file that is resumed on the next run and only renamed into
+// place once its byte count matches the expected size.
+func downloadFile(p Provider, downloadURL, outputDir, requestPath string, size int, sha string) error {
+	if downloadURL == "" {
+		return fmt.Errorf("no download URL for file %s", requestPath)
+	}
+
+	// Construct output file path using FilesRequest
+	filePath := filepath.Join(outputDir, requestPath)
+	partPath := filePath + ".part"
+	// Ensure parent directory exists
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %v", filePath, err)
+	}
+
+	// Resume from an earlier partial download if one exists
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil && info.Mode().IsRegular() {
+		offset = info.Size()
+		if size > 0 && offset >= int64(size) {
+			offset = 0
+		}
+	}
+
+	// Download file
+	stream, err := p.OpenFile(downloadURL, offset)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", downloadURL, err)
+	}
+	defer stream.Close()
+
+	// Append when the server resumed, otherwise start the partial file over
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if stream.Offset > 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %v", partPath, err)
+	}
+
+	written, err := io.Copy(file, stream)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save file %s: %v (rerun to resume)", filePath, err)
+	}
+
+	// Check the byte count against the listed size, or the size reported by the server
+	expected := int64(size)
+	if expected == 0 {
+		expected = stream.Size
+	}
+	if total := stream.Offset + written; expected >= 0 && total != expected {
+		// More bytes than expected means the partial file belonged to another version
+		if total > expected {
+			os.Remove(partPath)
+			return fmt.Errorf("incomplete download of %s: got %d of %d bytes", filePath, total, expected)
+		}
+		return fmt.Errorf("incomplete download of %s: got %d of %d bytes (rerun to resume)", filePath, total, expected)
+	}
+
+	// A resumed file may have been started from another version of the file, so
+	// check it against the listed SHA and start over when the two do not match
+	if stream.Offset > 0 && sha != "" {
+		if actual, err := utils.FileHash(partPath, sha); err == nil && actual != sha {
+			if err := os.Remove(partPath); err != nil {
+				return fmt.Errorf("failed to remove stale partial file %s: %v", partPath, err)
+			}
+			return downloadFile(p, downloadURL, outputDir, requestPath, size, sha)
+		}
+	}
+
+	if err := os.Rename(partPath, filePath); err != nil {
+		return fmt.Errorf("failed to save file %s: %v", filePath, err)
+	}
+
+	return nil
+}
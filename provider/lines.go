@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// commentStyles maps file extensions to the prefix and suffix of a line comment
+var commentStyles = map[string][2]string{
+	"c": {"// ", ""}, "h": {"// ", ""}, "cpp": {"// ", ""}, "hpp": {"// ", ""}, "cs": {"// ", ""},
+	"go": {"// ", ""}, "java": {"// ", ""}, "js": {"// ", ""}, "jsx": {"// ", ""}, "ts": {"// ", ""},
+	"tsx": {"// ", ""}, "kt": {"// ", ""}, "rs": {"// ", ""}, "swift": {"// ", ""}, "scala": {"// ", ""},
+	"dart": {"// ", ""}, "php": {"// ", ""}, "proto": {"// ", ""},
+	"py": {"# ", ""}, "rb": {"# ", ""}, "sh": {"# ", ""}, "bash": {"# ", ""}, "zsh": {"# ", ""},
+	"pl": {"# ", ""}, "r": {"# ", ""}, "yaml": {"# ", ""}, "yml": {"# ", ""}, "toml": {"# ", ""},
+	"ini": {"; ", ""}, "cfg": {"# ", ""}, "conf": {"# ", ""}, "dockerfile": {"# ", ""}, "mk": {"# ", ""},
+	"sql": {"-- ", ""}, "lua": {"-- ", ""}, "hs": {"-- ", ""},
+	"html": {"<!-- ", " -->"}, "xml": {"<!-- ", " -->"}, "md": {"<!-- ", " -->"}, "svg": {"<!-- ", " -->"},
+	"css": {"/* ", " */"}, "scss": {"// ", ""}, "less": {"// ", ""},
+	"tex": {"% ", ""}, "erl": {"% ", ""}, "vim": {"\" ", ""}, "el": {";; ", ""}, "clj": {";; ", ""},
+}
+
+// commentLine formats text as a line comment in the syntax of the named file,
+// falling back to # for unknown types
+func commentLine(name, text string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	if ext == "" {
+		ext = strings.ToLower(name)
+	}
+	style, ok := commentStyles[ext]
+	if !ok {
+		style = [2]string{"# ", ""}
+	}
+	return style[0] + text + style[1]
+}
+
+// ExtractLines writes lines parsed.LineStart to parsed.LineEnd of the single file in
+// structure to a file of the same name in outputDir, or to stdout when outputDir is "-".
+// With --permalink-header, a comment noting the permalink of the range is written first.
+func ExtractLines(p Provider, structure types.RepositoryStructure, parsed types.ParsedURL, outputDir string, args types.Args) error {
+	if parsed.RequestType != "file" || len(structure.Files) != 1 {
+		return fmt.Errorf("line ranges can only be extracted from a single file")
+	}
+
+	stream, err := p.OpenFile(structure.DownloadURLs[0], 0)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", structure.DownloadURLs[0], err)
+	}
+	defer stream.Close()
+
+	// Collect the range before touching the output, so a short file leaves nothing behind
+	var lines []string
+	reader := bufio.NewReader(stream)
+	for n := 1; n <= parsed.LineEnd; n++ {
+		line, err := reader.ReadString('\n')
+		if n >= parsed.LineStart && line != "" {
+			lines = append(lines, line)
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read %s: %v", structure.Files[0], err)
+		}
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("%s has fewer than %d lines", structure.Files[0], parsed.LineStart)
+	}
+	if last := lines[len(lines)-1]; !strings.HasSuffix(last, "\n") {
+		lines[len(lines)-1] = last + "\n"
+	}
+	end := parsed.LineStart + len(lines) - 1
+
+	var out io.Writer = os.Stdout
+	outputPath := "-"
+	if outputDir != "-" {
+		if outputDir == "" {
+			outputDir = "."
+		}
+		outputPath = filepath.Join(outputDir, structure.FilesRequest[0])
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return fmt.Errorf("failed to create parent directory for %s: %v", outputPath, err)
+		}
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create file %s: %v", outputPath, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer := bufio.NewWriter(out)
+	if args.PermalinkHeader {
+		permalink := fmt.Sprintf("%s#L%d", structure.FilesHTMLURL[0], parsed.LineStart)
+		if end > parsed.LineStart {
+			permalink += fmt.Sprintf("-L%d", end)
+		}
+		fmt.Fprintln(writer, commentLine(structure.FilesName[0], "Source: "+permalink))
+	}
+	for _, line := range lines {
+		writer.WriteString(line)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to save file %s: %v", outputPath, err)
+	}
+
+	if outputPath != "-" && !args.NoPrint {
+		fmt.Printf("Extracted lines %d-%d of %s to %s\n", parsed.LineStart, end, structure.Files[0], outputPath)
+	}
+	return nil
+}
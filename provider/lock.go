@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// LockFileName is the name of the provenance manifest written into the output directory
+const LockFileName = ".dgf.lock"
+
+// lockVersion is the schema version of the lockfile
+const lockVersion = 1
+
+// NewLock builds the provenance record of a structure fetched at the resolved commit
+func NewLock(structure types.RepositoryStructure, parsed types.ParsedURL, args types.Args) types.Lock {
+	lock := types.Lock{
+		Version:        lockVersion,
+		URL:            parsed.URL,
+		Platform:       parsed.ID,
+		Host:           parsed.Host,
+		Username:       parsed.Username,
+		Repo:           parsed.Repo,
+		RepoType:       parsed.RepoType,
+		Branch:         parsed.Branch,
+		Tag:            parsed.Tag,
+		Constraint:     args.Version,
+		Release:        parsed.Release,
+		Asset:          parsed.Asset,
+		Commit:         parsed.CommitSha,
+		Path:           parsed.Path,
+		RequestType:    parsed.RequestType,
+		Formats:        args.Formats,
+		ExcludeFormats: args.ExcludeFormats,
+		Sniff:          args.Sniff,
+		Include:        args.Include,
+		Exclude:        args.Exclude,
+		MinSize:        args.MinSize,
+		MaxSize:        args.MaxSize,
+		Files:          make([]types.LockFile, 0, len(structure.Files)),
+	}
+	for i := range structure.Files {
+		lock.Files = append(lock.Files, types.LockFile{
+			Path:     structure.FilesRequest[i],
+			RepoPath: structure.Files[i],
+			Size:     structure.FilesSize[i],
+			Sha:      structure.FilesSha[i],
+		})
+	}
+	return lock
+}
+
+// WriteLock writes the lockfile of a structure fetched at parsed.CommitSha into outputDir
+func WriteLock(structure types.RepositoryStructure, outputDir string, args types.Args, parsed types.ParsedURL) error {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	lock := NewLock(structure, parsed, args)
+	// Files listed without a size record the size they were downloaded with
+	for i, file := range lock.Files {
+		if file.Size > 0 {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(outputDir, file.Path)); err == nil && info.Mode().IsRegular() {
+			lock.Files[i].Size = int(info.Size())
+		}
+	}
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, LockFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %v", err)
+	}
+	return nil
+}
+
+// ReadLock reads the lockfile of a previous download in dir
+func ReadLock(dir string) (types.Lock, error) {
+	var lock types.Lock
+	data, err := os.ReadFile(filepath.Join(dir, LockFileName))
+	if err != nil {
+		return lock, err
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return lock, fmt.Errorf("failed to parse %s: %v", LockFileName, err)
+	}
+	return lock, nil
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// Process parses a platform URL or site args and fetches the repository structure
+func Process(p Provider, url string, args types.Args) (types.ParsedURL, types.RepositoryStructure, error) {
+	// Parse the URL and determine the reference to fetch
+	parsed, ref, err := Resolve(p, url, args)
+	if err != nil {
+		return parsed, types.RepositoryStructure{}, err
+	}
+	return List(p, parsed, ref, args)
+}
+
+// List determines the request type of the parsed path and fetches the repository
+// structure at ref, or the assets of the parsed release. With --sniff the format
+// filter is applied to the listed files by content as well as name.
+func List(p Provider, parsed types.ParsedURL, ref string, args types.Args) (types.ParsedURL, types.RepositoryStructure, error) {
+	parsed, structure, err := listFiles(p, parsed, ref, args)
+	if err == nil && args.Sniff {
+		structure = SniffFormats(p, structure, args)
+	}
+	return parsed, structure, err
+}
+
+// listFiles fetches the repository structure or release assets for List
+func listFiles(p Provider, parsed types.ParsedURL, ref string, args types.Args) (types.ParsedURL, types.RepositoryStructure, error) {
+	if parsed.Release != "" {
+		return listRelease(parsed, args)
+	}
+
+	// Determine request type if a path is specified
+	if parsed.Path != "" {
+		requestType, err := p.StatPath(parsed, ref)
+		if err != nil {
+			if err == ErrPathNotFound {
+				return parsed, types.RepositoryStructure{}, ErrPathNotFound
+			}
+			return parsed, types.RepositoryStructure{}, fmt.Errorf("failed to determine request type for path %s: %v", parsed.Path, err)
+		}
+		parsed.RequestType = requestType
+	}
+
+	// Fetch the repository structure, passing args for format filtering
+	structure, err := p.ListTree(parsed, ref, args)
+	if err != nil {
+		return parsed, structure, err
+	}
+
+	return parsed, structure, nil
+}
+
+// Resolve parses a platform URL or site args, applies --path and determines the ref to fetch.
+// The ref is resolved to a commit SHA once, so that the listing and every download read the
+// same snapshot even if the branch moves mid-run; the returned ref is that SHA.
+func Resolve(p Provider, url string, args types.Args) (types.ParsedURL, string, error) {
+	// Parse the URL or construct it from site args
+	parsed, err := p.ParseURL(url, args)
+	if err != nil {
+		return parsed, "", fmt.Errorf("failed to parse URL: %v", err)
+	}
+
+	// Split a ref that may contain slashes from the path that follows it
+	if err := splitRef(p, &parsed, args); err != nil {
+		return parsed, "", err
+	}
+
+	// Override path if provided via --path
+	if args.Path != "" {
+		SetPath(&parsed, args.Path)
+	}
+
+	// Override the line range if provided via --lines
+	if args.Lines != "" {
+		parsed.LineStart, parsed.LineEnd, _ = utils.ParseLineRange(args.Lines)
+	}
+
+	// Release assets are listed from the release instead of the tree
+	if args.Release != "" {
+		parsed.Release = args.Release
+	}
+	if args.Asset != "" {
+		parsed.Asset = args.Asset
+	}
+	if parsed.Release != "" {
+		return resolveRelease(p, parsed)
+	}
+
+	// Determine the reference (commit, tag or branch)
+	var ref string
+	if args.Commit != "" {
+		ref = args.Commit
+		parsed.Commit = args.Commit
+		parsed.Branch = ""
+	} else if args.Tag != "" || args.Version != "" {
+		ref = args.Tag
+		if args.Version != "" {
+			tag, err := ResolveVersion(p, parsed, args.Version)
+			if err != nil {
+				return parsed, "", err
+			}
+			ref = tag
+		}
+		parsed.Tag = ref
+		parsed.Commit = ""
+		parsed.Branch = ""
+	} else if parsed.Commit != "" {
+		ref = parsed.Commit
+	} else if args.Branch != "" {
+		ref = args.Branch
+		parsed.Branch = args.Branch
+	} else if parsed.Branch != "" {
+		ref = parsed.Branch
+	} else if parsed.Tag != "" {
+		ref = parsed.Tag
+	} else {
+		defaultBranch, err := p.DefaultBranch(parsed)
+		if err != nil {
+			return parsed, "", fmt.Errorf("failed to fetch default branch: %v", err)
+		}
+		ref = defaultBranch
+		parsed.Branch = defaultBranch
+	}
+
+	// Reconstruct the parsed URL with ref and path
+	parsed.URL = p.WebURL(parsed, ref)
+
+	// Pin the ref to the commit it currently points at
+	sha, err := p.ResolveCommit(parsed, ref)
+	if err != nil {
+		return parsed, "", fmt.Errorf("failed to resolve %s to a commit: %v", ref, err)
+	}
+	parsed.CommitSha = sha
+
+	return parsed, sha, nil
+}
+
+// SetPath sets the path of a parsed URL along with its parent and request parts
+func SetPath(parsed *types.ParsedURL, path string) {
+	parsed.Path = path
+	pathSegments := strings.Split(path, "/")
+	if len(pathSegments) > 1 {
+		parsed.ParentPath = strings.Join(pathSegments[:len(pathSegments)-1], "/")
+		parsed.RequestPath = pathSegments[len(pathSegments)-1]
+	} else {
+		parsed.ParentPath = ""
+		parsed.RequestPath = path
+	}
+}
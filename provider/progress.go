@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const barWidth = 20 // Width of the progress bar
+
+// progressBar prints a download progress bar that can be updated from multiple goroutines
+type progressBar struct {
+	mu      sync.Mutex
+	done    int
+	total   int
+	noPrint bool
+}
+
+// newProgressBar creates a progress bar for total files
+func newProgressBar(total int, noPrint bool) *progressBar {
+	return &progressBar{total: total, noPrint: noPrint}
+}
+
+// increment marks one more file as finished and redraws the bar
+func (b *progressBar) increment() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done++
+	b.draw()
+}
+
+// finish draws the completed bar and ends the line
+func (b *progressBar) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done = b.total
+	b.draw()
+	fmt.Println()
+}
+
+// draw prints the bar; the caller must hold the lock
+func (b *progressBar) draw() {
+	if b.noPrint || b.total == 0 {
+		return
+	}
+	filled := int(float64(b.done) / float64(b.total) * float64(barWidth))
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+	fmt.Printf("\r[%s] %d/%d", bar, b.done, b.total)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+var ErrPathNotFound = fmt.Errorf("path not found")
+
+// Provider is implemented by every hosting platform dgf can fetch from
+type Provider interface {
+	// ParseURL parses a platform URL or constructs one from site arguments
+	ParseURL(url string, args types.Args) (types.ParsedURL, error)
+	// WebURL builds the browsable URL for the parsed repository path at ref
+	WebURL(parsed types.ParsedURL, ref string) string
+	// DefaultBranch resolves the default branch of the parsed repository
+	DefaultBranch(parsed types.ParsedURL) (string, error)
+	// ResolveCommit resolves a branch, tag or short commit to its full commit SHA
+	ResolveCommit(parsed types.ParsedURL, ref string) (string, error)
+	// StatPath reports whether the parsed path is a "file" or a "dir" at ref
+	StatPath(parsed types.ParsedURL, ref string) (string, error)
+	// ListTree fetches the repository structure below the parsed path at ref
+	ListTree(parsed types.ParsedURL, ref string, args types.Args) (types.RepositoryStructure, error)
+	// OpenFile opens a stream for a file listed in RepositoryStructure.DownloadURLs,
+	// resuming at offset when the server supports range requests
+	OpenFile(downloadURL string, offset int64) (*FileStream, error)
+}
+
+// Factory creates a Provider for a platform configuration and access token
+type Factory func(platform types.Platform, token string) Provider
+
+// registry maps a Platform.ID to the factory of its provider
+var registry = map[string]Factory{}
+
+// Register makes a provider available for the given platform ID
+func Register(id string, factory Factory) {
+	if _, exists := registry[id]; exists {
+		panic(fmt.Sprintf("provider: Register called twice for platform %s", id))
+	}
+	registry[id] = factory
+}
+
+// New creates the provider registered for the platform
+func New(platform types.Platform, token string) (Provider, error) {
+	factory, exists := registry[platform.ID]
+	if !exists {
+		return nil, fmt.Errorf("platform %s not supported", platform.ID)
+	}
+	return factory(platform, token), nil
+}
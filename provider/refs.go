@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// Refs is implemented by providers that can look up branches and tags by name prefix
+type Refs interface {
+	// MatchRefs returns the names of the branches and tags that start with prefix
+	MatchRefs(parsed types.ParsedURL, prefix string) (branches, tags []string, err error)
+}
+
+// refMatch is a branch or tag that the ref-and-path part of a URL starts with
+type refMatch struct {
+	name string
+	tag  bool
+}
+
+// splitRef splits parsed.RefPath into a ref and the path below it. Ref names may
+// contain slashes, so like GitHub's own UI the longest branch or tag the URL starts
+// with wins, and branches win over tags of the same name. An explicit --branch or
+// --tag decides the split without any lookup.
+func splitRef(p Provider, parsed *types.ParsedURL, args types.Args) error {
+	refPath := parsed.RefPath
+	if refPath == "" {
+		return nil
+	}
+
+	explicit := refMatch{name: args.Branch}
+	if args.Tag != "" {
+		explicit = refMatch{name: args.Tag, tag: true}
+	}
+
+	var best refMatch
+	if explicit.name != "" && (refPath == explicit.name || strings.HasPrefix(refPath, explicit.name+"/")) {
+		best = explicit
+	} else {
+		refs, ok := p.(Refs)
+		if !ok {
+			return nil
+		}
+		prefix := strings.SplitN(refPath, "/", 2)[0]
+		branches, tags, err := refs.MatchRefs(*parsed, prefix)
+		if err != nil {
+			return fmt.Errorf("failed to look up refs matching %s: %v", prefix, err)
+		}
+
+		var matches []refMatch
+		for _, name := range branches {
+			if refPath == name || strings.HasPrefix(refPath, name+"/") {
+				matches = append(matches, refMatch{name: name})
+			}
+		}
+		for _, name := range tags {
+			if refPath == name || strings.HasPrefix(refPath, name+"/") {
+				matches = append(matches, refMatch{name: name, tag: true})
+			}
+		}
+		if len(matches) == 0 {
+			// Keep the first segment as the ref; the listing reports it as not found
+			return nil
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return len(matches[i].name) > len(matches[j].name) })
+		best = matches[0]
+
+		if len(matches) > 1 && !args.NoPrint {
+			var others []string
+			for _, match := range matches[1:] {
+				others = append(others, match.describe())
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %s is ambiguous; using %s over %s (pass --branch or --tag to choose)\n",
+				refPath, best.describe(), strings.Join(others, ", "))
+		}
+	}
+
+	parsed.Branch, parsed.Tag = best.name, ""
+	if best.tag {
+		parsed.Branch, parsed.Tag = "", best.name
+	}
+	SetPath(parsed, strings.TrimPrefix(strings.TrimPrefix(refPath, best.name), "/"))
+	return nil
+}
+
+// describe names the match as a branch or tag
+func (m refMatch) describe() string {
+	if m.tag {
+		return "tag " + m.name
+	}
+	return "branch " + m.name
+}
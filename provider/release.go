@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// Release is a published release and its downloadable assets
+type Release struct {
+	Tag    string
+	URL    string
+	Assets []types.TreeEntry
+}
+
+// Releases is implemented by providers that can download release assets
+type Releases interface {
+	// FetchRelease returns the release with the given tag, or the newest one for "latest"
+	FetchRelease(parsed types.ParsedURL, release string) (Release, error)
+}
+
+// resolveRelease pins a release request to the tag of the release and the commit it points at
+func resolveRelease(p Provider, parsed types.ParsedURL) (types.ParsedURL, string, error) {
+	releases, ok := p.(Releases)
+	if !ok {
+		return parsed, "", fmt.Errorf("release downloads are not supported for %s", parsed.Name)
+	}
+	release, err := releases.FetchRelease(parsed, parsed.Release)
+	if err != nil {
+		return parsed, "", err
+	}
+	parsed.Tag = release.Tag
+	parsed.URL = release.URL
+	parsed.Assets = release.Assets
+	parsed.RequestType = "release"
+
+	sha, err := p.ResolveCommit(parsed, release.Tag)
+	if err != nil {
+		return parsed, "", fmt.Errorf("failed to resolve %s to a commit: %v", release.Tag, err)
+	}
+	parsed.CommitSha = sha
+
+	return parsed, release.Tag, nil
+}
+
+// listRelease builds a structure of the assets of the release resolveRelease fetched
+// that match the asset glob and pass the format filter
+func listRelease(parsed types.ParsedURL, args types.Args) (types.ParsedURL, types.RepositoryStructure, error) {
+	structure := NewStructure()
+	for _, asset := range parsed.Assets {
+		if parsed.Asset != "" {
+			if matched, _ := path.Match(parsed.Asset, asset.Name); !matched {
+				continue
+			}
+		}
+		if !MatchFile(asset.Name, args) || !MatchSize(int64(asset.Size), args) {
+			continue
+		}
+		AddFile(&structure, asset, asset.Name)
+	}
+	if len(structure.Files) == 0 && len(parsed.Assets) > 0 && parsed.Asset != "" {
+		return parsed, structure, fmt.Errorf("no asset of release %s matches %s", parsed.Tag, parsed.Asset)
+	}
+
+	return parsed, structure, nil
+}
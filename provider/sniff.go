@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// Prefixes is implemented by providers that can fetch the first bytes of a file
+// with a range request
+type Prefixes interface {
+	// OpenPrefix opens the first length bytes of a file listed in DownloadURLs
+	OpenPrefix(downloadURL string, length int64) (io.ReadCloser, error)
+}
+
+// SniffFormats applies the --format filter to the files of structure by their content
+// as well as their name. The first bytes of every file whose name does not settle the
+// filter are fetched and classified, and a file passes when its name or its detected
+// type is included and neither is excluded.
+func SniffFormats(p Provider, structure types.RepositoryStructure, args types.Args) types.RepositoryStructure {
+	jobs := args.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	keep := make([]bool, len(structure.Files))
+	sniffErrors := make([]error, len(structure.Files))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				keep[i], sniffErrors[i] = sniffFile(p, structure, i, args)
+			}
+		}()
+	}
+	for i := range structure.Files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if !args.NoPrint {
+		for i, err := range sniffErrors {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not sniff %s, matching by name only: %v\n", structure.Files[i], err)
+			}
+		}
+	}
+	return SelectFiles(structure, func(i int) bool { return keep[i] })
+}
+
+// sniffFile decides whether file i of structure passes the format filter, fetching
+// its first bytes only when its name does not decide it
+func sniffFile(p Provider, structure types.RepositoryStructure, i int, args types.Args) (bool, error) {
+	name := structure.FilesName[i]
+	if decided, keep := matchFormatByName(name, args); decided {
+		return keep, nil
+	}
+
+	head, err := readPrefix(p, structure.DownloadURLs[i])
+	if err != nil {
+		return utils.MatchFormat(name, args.Formats, args.ExcludeFormats), err
+	}
+	return MatchSniffed(name, utils.DetectExtension(head), args), nil
+}
+
+// matchFormatByName reports whether the name of a file alone decides the format
+// filter, and if so, whether the file passes it
+func matchFormatByName(name string, args types.Args) (bool, bool) {
+	if !utils.MatchFormat(name, nil, args.ExcludeFormats) {
+		return true, false
+	}
+	if len(args.ExcludeFormats) == 0 && utils.MatchFormat(name, args.Formats, nil) {
+		return true, true
+	}
+	return false, false
+}
+
+// MatchSniffed reports whether a file passes the format filter given its name and the
+// extension of its detected type, which is empty when the content was not recognised
+func MatchSniffed(name, detected string, args types.Args) bool {
+	if detected == "" {
+		return utils.MatchFormat(name, args.Formats, args.ExcludeFormats)
+	}
+	typeName := "file." + detected
+	included := utils.MatchFormat(name, args.Formats, nil) || utils.MatchFormat(typeName, args.Formats, nil)
+	excluded := !utils.MatchFormat(name, nil, args.ExcludeFormats) || !utils.MatchFormat(typeName, nil, args.ExcludeFormats)
+	return included && !excluded
+}
+
+// readPrefix fetches the first bytes of a file, with a range request when the
+// provider supports one
+func readPrefix(p Provider, downloadURL string) ([]byte, error) {
+	if downloadURL == "" {
+		return nil, fmt.Errorf("no download URL")
+	}
+	var stream io.ReadCloser
+	var err error
+	if prefixes, ok := p.(Prefixes); ok {
+		stream, err = prefixes.OpenPrefix(downloadURL, utils.SniffLength)
+	} else {
+		stream, err = p.OpenFile(downloadURL, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	return io.ReadAll(io.LimitReader(stream, utils.SniffLength))
+}
+
+// SniffLocalFile reads the first bytes of a local file and returns the extension of
+// its detected type
+func SniffLocalFile(filePath string) string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	head, _ := io.ReadAll(io.LimitReader(file, utils.SniffLength))
+	return utils.DetectExtension(head)
+}
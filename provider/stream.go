@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// FileStream is an open download stream of a file
+type FileStream struct {
+	io.ReadCloser
+	Offset int64 // Byte offset the stream starts at
+	Size   int64 // Total size of the file, or -1 if the server did not report it
+}
+
+// OpenStream sends req asking for the bytes from offset on. Servers that ignore the
+// Range header answer with the whole file, which is reported by an Offset of 0.
+func OpenStream(req *http.Request, offset int64) (*FileStream, error) {
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &FileStream{ReadCloser: resp.Body, Offset: 0, Size: resp.ContentLength}, nil
+	case http.StatusPartialContent:
+		var start, end, size int64
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &size); err != nil || start != offset {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		return &FileStream{ReadCloser: resp.Body, Offset: start, Size: size}, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is stale or already complete, start over
+		resp.Body.Close()
+		req.Header.Del("Range")
+		return OpenStream(req, 0)
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrPathNotFound
+	}
+
+	resp.Body.Close()
+	return nil, fmt.Errorf("status %d", resp.StatusCode)
+}
+
+// OpenPrefix sends req asking for the first length bytes of a file. Servers that
+// ignore the Range header answer with the whole file, which is cut off at length.
+func OpenPrefix(req *http.Request, length int64) (io.ReadCloser, error) {
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", length-1))
+
+	resp, err := utils.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, length), resp.Body}, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Empty files have no first byte
+		resp.Body.Close()
+		return io.NopCloser(strings.NewReader("")), nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrPathNotFound
+	}
+
+	resp.Body.Close()
+	return nil, fmt.Errorf("status %d", resp.StatusCode)
+}
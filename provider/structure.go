@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"path"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// NewStructure returns an empty repository structure with initialized slices
+func NewStructure() types.RepositoryStructure {
+	return types.RepositoryStructure{
+		Files:        []string{},
+		FilesName:    []string{},
+		FilesSha:     []string{},
+		FilesHTMLURL: []string{},
+		FilesGitURL:  []string{},
+		FilesURL:     []string{},
+		FilesSize:    []int{},
+		Folders:      []string{},
+		DownloadURLs: []string{},
+		FilesRequest: []string{},
+	}
+}
+
+// AddFile appends a file entry to the structure under the given request path
+func AddFile(structure *types.RepositoryStructure, entry types.TreeEntry, requestPath string) {
+	structure.Files = append(structure.Files, entry.Path)
+	structure.FilesName = append(structure.FilesName, entry.Name)
+	structure.FilesSha = append(structure.FilesSha, entry.Sha)
+	structure.FilesHTMLURL = append(structure.FilesHTMLURL, entry.HTMLURL)
+	structure.FilesGitURL = append(structure.FilesGitURL, entry.GitURL)
+	structure.FilesURL = append(structure.FilesURL, entry.URL)
+	structure.FilesSize = append(structure.FilesSize, entry.Size)
+	structure.DownloadURLs = append(structure.DownloadURLs, entry.DownloadURL)
+	structure.FilesRequest = append(structure.FilesRequest, requestPath)
+}
+
+// SelectFiles returns a structure holding only the files for which keep returns true,
+// along with the folders that still contain one of them
+func SelectFiles(structure types.RepositoryStructure, keep func(i int) bool) types.RepositoryStructure {
+	selected := NewStructure()
+	for i := range structure.Files {
+		if !keep(i) {
+			continue
+		}
+		AddFile(&selected, types.TreeEntry{
+			Name:        structure.FilesName[i],
+			Path:        structure.Files[i],
+			Type:        "file",
+			Size:        structure.FilesSize[i],
+			Sha:         structure.FilesSha[i],
+			URL:         structure.FilesURL[i],
+			HTMLURL:     structure.FilesHTMLURL[i],
+			GitURL:      structure.FilesGitURL[i],
+			DownloadURL: structure.DownloadURLs[i],
+		}, structure.FilesRequest[i])
+	}
+	for _, folder := range structure.Folders {
+		for _, requestPath := range selected.FilesRequest {
+			if strings.HasPrefix(requestPath, folder+"/") {
+				selected.Folders = append(selected.Folders, folder)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// MatchFile reports whether the file at a repository path passes the --format,
+// --include and --exclude filters. With --sniff the format filter is left to
+// SniffFormats, which also looks at the content.
+func MatchFile(filePath string, args types.Args) bool {
+	if !args.Sniff && !utils.MatchFormat(path.Base(filePath), args.Formats, args.ExcludeFormats) {
+		return false
+	}
+	return utils.MatchGlobs(filePath, args.Include, args.Exclude)
+}
+
+// MatchSize reports whether a file of the given size passes the --min-size and
+// --max-size filters
+func MatchSize(size int64, args types.Args) bool {
+	return size >= args.MinSize && (args.MaxSize == 0 || size <= args.MaxSize)
+}
+
+// BuildStructure builds a repository structure from a flat recursive listing of path,
+// keeping only files that pass the filters and folders that contain them
+func BuildStructure(entries []types.TreeEntry, requestPath string, args types.Args) types.RepositoryStructure {
+	structure := NewStructure()
+
+	// Determine parent path for relative path construction
+	var parentPath string
+	if requestPath != "" {
+		pathSegments := strings.Split(requestPath, "/")
+		if len(pathSegments) > 1 {
+			parentPath = strings.Join(pathSegments[:len(pathSegments)-1], "/")
+		}
+	}
+
+	seenFolders := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Type != "file" {
+			continue
+		}
+		if requestPath != "" && !strings.HasPrefix(entry.Path, requestPath+"/") {
+			continue
+		}
+
+		// Apply format, glob and size filtering
+		if !MatchFile(entry.Path, args) || !MatchSize(int64(entry.Size), args) {
+			continue
+		}
+
+		// Add every folder between the requested path and the file
+		folders := strings.Split(entry.Path, "/")
+		for i := range folders[:len(folders)-1] {
+			folder := strings.Join(folders[:i+1], "/")
+			if len(folder) <= len(requestPath) || seenFolders[folder] {
+				continue
+			}
+			seenFolders[folder] = true
+			structure.Folders = append(structure.Folders, relativePath(folder, parentPath))
+		}
+
+		AddFile(&structure, entry, relativePath(entry.Path, parentPath))
+	}
+
+	return structure
+}
+
+// relativePath strips parentPath from itemPath when itemPath lies below it
+func relativePath(itemPath, parentPath string) string {
+	if parentPath != "" && strings.HasPrefix(itemPath, parentPath+"/") {
+		return strings.TrimPrefix(itemPath, parentPath+"/")
+	}
+	return itemPath
+}
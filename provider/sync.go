@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// SyncPlan lists what has to change for a local directory to match a repository structure
+type SyncPlan struct {
+	Added     []int    // Indexes of files missing locally
+	Updated   []int    // Indexes of local files whose hash differs from the listed SHA
+	Unchanged []int    // Indexes of local files that already match
+	Removed   []string // Request paths of local files that no longer exist upstream
+	Unscoped  bool     // No lockfile or requested folder limited the search for removed files
+}
+
+// vcsDirs are never searched for removed files
+var vcsDirs = map[string]bool{".git": true, ".hg": true, ".svn": true}
+
+// PlanSync compares the files below outputDir with the structure using git blob hashes.
+// Local files are only considered for removal when they pass the filters and either are
+// recorded in the lockfile of an earlier download or, without one, lie below the
+// requested folder, so unrelated files in the output directory are never touched.
+func PlanSync(structure types.RepositoryStructure, parsed types.ParsedURL, outputDir string, args types.Args) (SyncPlan, error) {
+	var plan SyncPlan
+	upstream := make(map[string]bool, len(structure.FilesRequest))
+	for i, requestPath := range structure.FilesRequest {
+		upstream[filepath.ToSlash(requestPath)] = true
+
+		filePath := filepath.Join(outputDir, requestPath)
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			plan.Added = append(plan.Added, i)
+			continue
+		}
+
+		// Files without a listed SHA cannot be compared and are fetched again
+		expected := strings.ToLower(structure.FilesSha[i])
+		if expected == "" {
+			plan.Updated = append(plan.Updated, i)
+			continue
+		}
+		actual, err := utils.FileHash(filePath, expected)
+		if err != nil || actual != expected {
+			plan.Updated = append(plan.Updated, i)
+			continue
+		}
+		plan.Unchanged = append(plan.Unchanged, i)
+	}
+
+	// Find local files that are no longer part of the repository
+	if parsed.RequestType == "file" {
+		return plan, nil
+	}
+	removed := func(requestPath string) bool {
+		slashPath := filepath.ToSlash(requestPath)
+		return !upstream[slashPath] && matchLocalFile(slashPath, filepath.Join(outputDir, requestPath), parsed, args)
+	}
+
+	// The lockfile names exactly the files an earlier download wrote
+	if lock, err := ReadLock(outputDir); err == nil {
+		for _, file := range lock.Files {
+			if parsed.RequestPath != "" && file.Path != parsed.RequestPath && !strings.HasPrefix(file.Path, parsed.RequestPath+"/") {
+				continue
+			}
+			requestPath := filepath.FromSlash(file.Path)
+			if info, err := os.Stat(filepath.Join(outputDir, requestPath)); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			if removed(requestPath) {
+				plan.Removed = append(plan.Removed, requestPath)
+			}
+		}
+		return plan, nil
+	}
+
+	// Without a lockfile only the requested folder is searched, never the whole output directory
+	if parsed.RequestPath == "" || parsed.Release != "" {
+		plan.Unscoped = true
+		return plan, nil
+	}
+	scope := filepath.Join(outputDir, parsed.RequestPath)
+	err := filepath.WalkDir(scope, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == scope {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() && vcsDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() == LockFileName || strings.HasSuffix(path, ".part") {
+			return nil
+		}
+		requestPath, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		if removed(requestPath) {
+			plan.Removed = append(plan.Removed, requestPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return plan, fmt.Errorf("failed to scan %s: %v", scope, err)
+	}
+
+	return plan, nil
+}
+
+// matchLocalFile reports whether a local file passes the filters of the listing. Files
+// the filters leave out were never part of the listing and must not be removed.
+func matchLocalFile(requestPath, filePath string, parsed types.ParsedURL, args types.Args) bool {
+	repoPath := requestPath
+	if parsed.ParentPath != "" {
+		repoPath = parsed.ParentPath + "/" + repoPath
+	}
+	if !MatchFile(repoPath, args) {
+		return false
+	}
+	if args.Sniff && !MatchSniffed(filepath.Base(filePath), SniffLocalFile(filePath), args) {
+		return false
+	}
+	info, err := os.Stat(filePath)
+	return err == nil && MatchSize(info.Size(), args)
+}
+
+// Sync downloads only new and changed files into outputDir and, with --delete,
+// removes local files that no longer exist upstream
+func Sync(p Provider, structure types.RepositoryStructure, outputDir string, args types.Args, parsed types.ParsedURL) error {
+	// Clean the path so the folder cleanup below stops at the output directory
+	outputDir = filepath.Clean(outputDir)
+	plan, err := PlanSync(structure, parsed, outputDir, args)
+	if err != nil {
+		return err
+	}
+
+	// Only the missing and changed files count against the budget
+	changed := make(map[int]bool, len(plan.Added)+len(plan.Updated))
+	for _, i := range append(plan.Added, plan.Updated...) {
+		changed[i] = true
+	}
+	download := SelectFiles(structure, func(i int) bool { return changed[i] })
+	if err := CheckBudget(download, args); err != nil {
+		return err
+	}
+
+	// Remove files that disappeared upstream, along with folders left empty
+	removed := 0
+	if args.Delete {
+		for _, requestPath := range plan.Removed {
+			filePath := filepath.Join(outputDir, requestPath)
+			if err := os.Remove(filePath); err != nil {
+				if !args.NoPrint {
+					fmt.Fprintf(os.Stderr, "Error removing %s: %v\n", filePath, err)
+				}
+				continue
+			}
+			removed++
+			for dir := filepath.Dir(filePath); dir != outputDir && dir != "."; dir = filepath.Dir(dir) {
+				if os.Remove(dir) != nil {
+					break
+				}
+			}
+		}
+	}
+
+	// Download the files that are missing or changed
+	if len(changed) > 0 {
+		if err := Download(p, download, outputDir, args, parsed); err != nil {
+			return err
+		}
+	}
+
+	// Report the counts only once the download succeeded
+	if !args.NoPrint {
+		fmt.Printf("SYNC: %d added, %d updated, %d removed, %d unchanged\n", len(plan.Added), len(plan.Updated), removed, len(plan.Unchanged))
+		if args.Delete && plan.Unscoped {
+			fmt.Fprintf(os.Stderr, "Warning: no %s in %s, so --delete only removes files below a requested folder\n", LockFileName, outputDir)
+		}
+		if !args.Delete && len(plan.Removed) > 0 {
+			fmt.Printf("%d local files no longer exist upstream (use --delete to remove them)\n", len(plan.Removed))
+		}
+		if len(changed) == 0 {
+			fmt.Println("DONE")
+		}
+	}
+	return nil
+}
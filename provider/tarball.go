@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// Tarball is implemented by providers that can stream a gzip-compressed
+// archive of a whole repository at a ref
+type Tarball interface {
+	// OpenTarball opens a .tar.gz stream of the repository at ref
+	OpenTarball(parsed types.ParsedURL, ref string) (io.ReadCloser, error)
+}
+
+// DownloadTarball streams the repository archive at ref and extracts the entries
+// under parsed.Path that pass the format filter into the output directory. It returns
+// an error if any entry could not be written.
+func DownloadTarball(p Provider, parsed types.ParsedURL, ref, outputDir string, args types.Args) error {
+	tarball, ok := p.(Tarball)
+	if !ok {
+		return fmt.Errorf("tarball mode is not supported for %s", parsed.Name)
+	}
+
+	// Validate output directory
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("error ensuring output directory %s exists: %v", outputDir, err)
+	}
+
+	// Print header if NoPrint is false
+	if !args.NoPrint {
+		fmt.Println()
+		fmt.Printf("Downloading %s tarball\n", parsed.ID)
+		fmt.Println()
+		fmt.Printf("REPO: %s/%s\n", parsed.Username, parsed.Repo)
+		fmt.Printf("PATH: %s\n", parsed.Path)
+		if parsed.Commit != "" {
+			fmt.Printf("COMMIT: %s\n", parsed.Commit)
+		} else if parsed.Tag != "" {
+			fmt.Printf("TAG: %s\n", parsed.Tag)
+		} else if parsed.Branch != "" {
+			fmt.Printf("BRANCH: %s\n", parsed.Branch)
+		}
+		if len(args.Formats) > 0 {
+			fmt.Printf("FORMATS: %v\n", args.Formats)
+		}
+		if len(args.ExcludeFormats) > 0 {
+			fmt.Printf("EXCLUDED FORMATS: %v\n", args.ExcludeFormats)
+		}
+		fmt.Printf("SAVED IN: %s\n", outputDir)
+		fmt.Println()
+	}
+
+	stream, err := tarball.OpenTarball(parsed, ref)
+	if err != nil {
+		return fmt.Errorf("failed to open tarball: %v", err)
+	}
+	defer stream.Close()
+
+	gzipReader, err := gzip.NewReader(stream)
+	if err != nil {
+		return fmt.Errorf("failed to read tarball: %v", err)
+	}
+	defer gzipReader.Close()
+
+	// Extract matching entries one by one as they arrive
+	var fileSizes []int
+	pathFound := false
+	var folders = make(map[string]bool)
+	var downloadMessages []string
+	failedFiles := 0
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Strip the top-level <repo>-<sha>/ directory of the archive
+		_, itemPath, found := strings.Cut(header.Name, "/")
+		if !found || itemPath == "" {
+			continue
+		}
+		if parsed.Path != "" && itemPath != parsed.Path && !strings.HasPrefix(itemPath, parsed.Path+"/") {
+			continue
+		}
+		pathFound = true
+
+		// Apply format, glob and size filtering
+		if !MatchFile(itemPath, args) || !MatchSize(header.Size, args) {
+			continue
+		}
+
+		requestPath := relativePath(itemPath, parsed.ParentPath)
+		if !filepath.IsLocal(requestPath) {
+			downloadMessages = append(downloadMessages, fmt.Sprintf("Skipping unsafe path %s", itemPath))
+			continue
+		}
+		filePath := filepath.Join(outputDir, requestPath)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			downloadMessages = append(downloadMessages, fmt.Sprintf("Error creating parent directory for %s: %v", filePath, err))
+			failedFiles++
+			continue
+		}
+		for dir := path.Dir(requestPath); dir != "."; dir = path.Dir(dir) {
+			folders[dir] = true
+		}
+
+		file, err := os.Create(filePath)
+		if err != nil {
+			downloadMessages = append(downloadMessages, fmt.Sprintf("Error creating file %s: %v", filePath, err))
+			failedFiles++
+			continue
+		}
+		_, err = io.Copy(file, tarReader)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("error saving file %s: %v", filePath, err)
+		}
+
+		fileSizes = append(fileSizes, int(header.Size))
+		if !args.NoPrint {
+			fmt.Printf("\rExtracted %d files", len(fileSizes))
+		}
+	}
+
+	if parsed.Path != "" && !pathFound {
+		return ErrPathNotFound
+	}
+
+	// Print summary and messages if NoPrint is false
+	if !args.NoPrint {
+		if len(fileSizes) > 0 {
+			fmt.Println()
+			fmt.Println()
+		}
+		fmt.Printf("SIZE: %s\n", utils.FormatSize(fileSizes))
+		fmt.Printf("OBJECTS: (%d files, %d folders)\n", len(fileSizes), len(folders))
+		if failedFiles > 0 {
+			fmt.Printf("FAILED: %d of %d files\n", failedFiles, failedFiles+len(fileSizes))
+		}
+		for _, msg := range downloadMessages {
+			fmt.Println(msg)
+		}
+		fmt.Println("DONE")
+	}
+
+	if failedFiles > 0 {
+		return fmt.Errorf("%d of %d files failed to extract", failedFiles, failedFiles+len(fileSizes))
+	}
+	return nil
+}
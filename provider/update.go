@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NeerajCodz/dgf/types"
+)
+
+// Update refreshes a previous download in outputDir from its lockfile. It resolves the
+// current head of the recorded branch, shows which files changed since the recorded
+// commit and applies only those changes.
+func Update(p Provider, lock types.Lock, outputDir string, args types.Args) error {
+	// Replay the original request
+	args.URL = lock.URL
+	args.Path = lock.Path
+	args.Formats = lock.Formats
+	args.ExcludeFormats = lock.ExcludeFormats
+	args.Sniff = lock.Sniff
+	args.Include = lock.Include
+	args.Exclude = lock.Exclude
+	args.MinSize = lock.MinSize
+	args.MaxSize = lock.MaxSize
+	args.Branch = lock.Branch
+	args.Tag = ""
+	args.Version = lock.Constraint
+	args.Commit = ""
+	args.Release = lock.Release
+	args.Asset = lock.Asset
+	if lock.Constraint == "" && lock.Tag != "" {
+		args.Tag = lock.Tag
+	} else if lock.Constraint == "" && lock.Branch == "" {
+		args.Commit = lock.Commit
+	}
+	parsed, ref, err := Resolve(p, lock.URL, args)
+	if err != nil {
+		return err
+	}
+
+	// Compare the head with the recorded commit before listing anything
+	head := parsed.CommitSha
+	name := parsed.Branch
+	if parsed.Tag != "" {
+		name = parsed.Tag
+	} else if name == "" {
+		name = parsed.Commit
+	}
+	if head == lock.Commit {
+		if !args.NoPrint {
+			fmt.Printf("Already up to date at %s (%s)\n", shortSha(head), name)
+		}
+		return nil
+	}
+
+	parsed, structure, err := List(p, parsed, ref, args)
+	if err != nil {
+		return err
+	}
+
+	// Diff the new listing against the files recorded in the lockfile
+	recorded := make(map[string]string, len(lock.Files))
+	for _, file := range lock.Files {
+		recorded[file.Path] = file.Sha
+	}
+	var changes []string
+	changed := make(map[int]bool)
+	for i, requestPath := range structure.FilesRequest {
+		sha, exists := recorded[requestPath]
+		delete(recorded, requestPath)
+		if !exists {
+			changes = append(changes, "A "+requestPath)
+			changed[i] = true
+		} else if sha != structure.FilesSha[i] {
+			changes = append(changes, "M "+requestPath)
+			changed[i] = true
+		}
+	}
+	for _, file := range lock.Files {
+		if _, removed := recorded[file.Path]; removed {
+			changes = append(changes, "D "+file.Path)
+		}
+	}
+
+	if !args.NoPrint {
+		fmt.Printf("Updating %s/%s %s: %s -> %s\n", lock.Username, lock.Repo, name, shortSha(lock.Commit), shortSha(head))
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+		if len(changes) == 0 {
+			fmt.Println("  (no file changes)")
+		}
+	}
+
+	download := SelectFiles(structure, func(i int) bool { return changed[i] })
+	if err := CheckBudget(download, args); err != nil {
+		return err
+	}
+
+	// Remove files that no longer exist upstream
+	for path := range recorded {
+		filePath := filepath.Join(outputDir, path)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %v", filePath, err)
+		}
+	}
+
+	// Download added and modified files
+	if len(changed) > 0 {
+		if err := Download(p, download, outputDir, args, parsed); err != nil {
+			return err
+		}
+	}
+
+	return WriteLock(structure, outputDir, args, parsed)
+}
+
+// shortSha abbreviates a commit SHA for display
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// VerifyReport summarizes an integrity check of downloaded files
+type VerifyReport struct {
+	Verified   int      // Files whose hash matches the listed SHA
+	Skipped    int      // Files without a listed SHA
+	Mismatches []string // Messages for missing, unreadable or mismatching files
+}
+
+// Verify compares every file of the structure found below dir with its listed SHA.
+// Mismatching files are deleted when remove is set so a rerun downloads them again.
+func Verify(structure types.RepositoryStructure, dir string, remove bool) VerifyReport {
+	var report VerifyReport
+	for i, requestPath := range structure.FilesRequest {
+		expected := strings.ToLower(structure.FilesSha[i])
+		if expected == "" {
+			report.Skipped++
+			continue
+		}
+
+		filePath := filepath.Join(dir, requestPath)
+		actual, err := utils.FileHash(filePath, expected)
+		if err != nil {
+			if os.IsNotExist(err) {
+				report.Mismatches = append(report.Mismatches, fmt.Sprintf("Missing file %s", filePath))
+			} else {
+				report.Mismatches = append(report.Mismatches, fmt.Sprintf("Error hashing %s: %v", filePath, err))
+			}
+			continue
+		}
+		if actual == expected {
+			report.Verified++
+			continue
+		}
+
+		msg := fmt.Sprintf("SHA mismatch for %s: expected %s, got %s", filePath, expected, actual)
+		if remove {
+			if err := os.Remove(filePath); err != nil {
+				msg += fmt.Sprintf(" (error deleting: %v)", err)
+			} else {
+				msg += " (deleted)"
+			}
+		}
+		report.Mismatches = append(report.Mismatches, msg)
+	}
+	return report
+}
+
+// VerifyLock re-checks a previous download in dir against the SHAs recorded in its
+// lockfile, without listing the repository again
+func VerifyLock(dir string, remove bool) (VerifyReport, error) {
+	lock, err := ReadLock(dir)
+	if err != nil {
+		return VerifyReport{}, fmt.Errorf("no usable %s in %s: %v", LockFileName, dir, err)
+	}
+	structure := NewStructure()
+	for _, file := range lock.Files {
+		entry := types.TreeEntry{Name: path.Base(file.RepoPath), Path: file.RepoPath, Type: "file", Sha: file.Sha, Size: file.Size}
+		AddFile(&structure, entry, file.Path)
+	}
+	return Verify(structure, dir, remove), nil
+}
+
+// Print prints the summary line and every mismatch of the report
+func (r VerifyReport) Print() {
+	fmt.Printf("VERIFIED: %d files, %d mismatches", r.Verified, len(r.Mismatches))
+	if r.Skipped > 0 {
+		fmt.Printf(", %d without SHA", r.Skipped)
+	}
+	fmt.Println()
+	for _, msg := range r.Mismatches {
+		fmt.Println(msg)
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/NeerajCodz/dgf/types"
+	"github.com/NeerajCodz/dgf/utils"
+)
+
+// Tags is implemented by providers that can list the tags of a repository
+type Tags interface {
+	// ListTags returns the names of all tags in the repository
+	ListTags(parsed types.ParsedURL) ([]string, error)
+}
+
+// ResolveVersion lists the repository tags and returns the one with the highest
+// semantic version matching constraint. Tags that are not versions are ignored.
+func ResolveVersion(p Provider, parsed types.ParsedURL, constraint string) (string, error) {
+	tags, ok := p.(Tags)
+	if !ok {
+		return "", fmt.Errorf("--version is not supported for %s", parsed.Name)
+	}
+	c, err := utils.ParseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	names, err := tags.ListTags(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %v", err)
+	}
+	var best string
+	var bestVersion utils.Version
+	for _, name := range names {
+		version, ok := utils.ParseVersion(name)
+		if !ok || !c.Match(version) {
+			continue
+		}
+		if best == "" || version.Compare(bestVersion) > 0 {
+			best, bestVersion = name, version
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no tag of %s/%s matches version %s", parsed.Username, parsed.Repo, constraint)
+	}
+	return best, nil
+}
@@ -2,19 +2,42 @@ package types
 
 // Args represents command-line arguments
 type Args struct {
-	URL       string
-	Site      string
-	Username  string
-	Repo      string
-	Token     string
-	Branch    string
-	Commit    string
-	Path      string
-	NoPrint   bool
-	PrintTree bool
-	Check     bool
-	PrintInfo bool
-	Output    string
-	Formats   []string
+	Command         string
+	URL             string
+	Site            string
+	Username        string
+	Repo            string
+	Token           string
+	Branch          string
+	Commit          string
+	Tag             string
+	Version         string
+	Release         string
+	Asset           string
+	Lines           string
+	PermalinkHeader bool
+	Path            string
+	NoPrint         bool
+	PrintTree       bool
+	Check           bool
+	PrintInfo       bool
+	Tarball         bool
+	Jobs            int
+	WaitLimit       bool
+	VerifySha       bool
+	VerifyDir       string
+	DeleteMismatch  bool
+	Sync            bool
+	Delete          bool
+	Archive         string
+	Output          string
+	Formats         []string
+	ExcludeFormats  []string
+	Sniff           bool
+	Config          string // User format config, instead of the default location
+	Include         []string
+	Exclude         []string
+	MinSize         int64 // Smallest file size to download in bytes, 0 for no limit
+	MaxSize         int64 // Largest file size to download in bytes, 0 for no limit
+	MaxTotal        int64 // Download budget in bytes, 0 for no limit
 }
-
@@ -12,8 +12,10 @@ type Platform struct {
 
 // URL represents the base URLs for a platform
 type URL struct {
-	Site []string `json:"site"`
-	Raw  []string `json:"raw"`
+	Site      []string `json:"site"`
+	Raw       []string `json:"raw"`
+	Pattern   []string `json:"pattern,omitempty"`   // URL fragments identifying self-hosted instances or other URL forms
+	Shorthand bool     `json:"shorthand,omitempty"` // Whether owner/repo shorthand refers to this platform
 }
 
 // URLStruc represents the URL structure templates for a platform
@@ -34,17 +36,27 @@ type RawURLStruc struct {
 
 // ParsedURL represents parsed components of a platform URL
 type ParsedURL struct {
-	URL         string `json:"url"`
-	Name        string `json:"name"`
-	ID          string `json:"id"`
-	Username    string `json:"username"`
-	Repo        string `json:"repo"`
-	Branch      string `json:"branch"`
-	Commit      string `json:"commit"`
-	Path        string `json:"path"`
-	ParentPath  string `json:"parent_path"`
-	RequestPath string `json:"request_path"`
-	RequestType string `json:"request_type"` // file or dir
+	URL         string      `json:"url"`
+	Name        string      `json:"name"`
+	ID          string      `json:"id"`
+	Host        string      `json:"host,omitempty"`
+	Username    string      `json:"username"`
+	Repo        string      `json:"repo"`
+	RepoType    string      `json:"repo_type,omitempty"` // model, dataset or space on HuggingFace
+	Branch      string      `json:"branch"`
+	Commit      string      `json:"commit"`
+	Tag         string      `json:"tag,omitempty"`
+	Release     string      `json:"release,omitempty"` // Release tag, or latest, to download assets of
+	Asset       string      `json:"asset,omitempty"`   // Glob selecting release assets
+	CommitSha   string      `json:"commit_sha"`        // Commit the ref resolved to when the request started
+	Path        string      `json:"path"`
+	ParentPath  string      `json:"parent_path"`
+	RequestPath string      `json:"request_path"`
+	RequestType string      `json:"request_type"`         // file or dir
+	LineStart   int         `json:"line_start,omitempty"` // First line of a #L anchor or --lines range
+	LineEnd     int         `json:"line_end,omitempty"`
+	RefPath     string      `json:"-"` // Unsplit <ref>/<path> of a URL whose ref may contain slashes
+	Assets      []TreeEntry `json:"-"` // Assets of the release a release request resolved to
 }
 
 // GitHubContent represents an item in a GitHub repository's contents
@@ -72,4 +84,52 @@ type RepositoryStructure struct {
 	Folders      []string `json:"folders"`
 	DownloadURLs []string `json:"download_urls"`
 	FilesRequest []string `json:"files_request"`
-}
\ No newline at end of file
+}
+
+// TreeEntry represents a file or directory returned by a recursive listing
+type TreeEntry struct {
+	Name        string
+	Path        string
+	Type        string // file or dir
+	Size        int
+	Sha         string
+	URL         string
+	HTMLURL     string
+	GitURL      string
+	DownloadURL string
+}
+
+// Lock records the provenance of a download in the .dgf.lock file of the output directory
+type Lock struct {
+	Version        int        `json:"version"`
+	URL            string     `json:"url"`
+	Platform       string     `json:"platform"`
+	Host           string     `json:"host,omitempty"`
+	Username       string     `json:"username"`
+	Repo           string     `json:"repo"`
+	RepoType       string     `json:"repo_type,omitempty"`
+	Branch         string     `json:"branch,omitempty"`
+	Tag            string     `json:"tag,omitempty"`
+	Constraint     string     `json:"constraint,omitempty"` // --version constraint the tag was chosen by
+	Release        string     `json:"release,omitempty"`
+	Asset          string     `json:"asset,omitempty"`
+	Commit         string     `json:"commit"`
+	Path           string     `json:"path"`
+	RequestType    string     `json:"request_type,omitempty"`
+	Formats        []string   `json:"formats,omitempty"`
+	ExcludeFormats []string   `json:"exclude_formats,omitempty"`
+	Sniff          bool       `json:"sniff,omitempty"`
+	Include        []string   `json:"include,omitempty"`
+	Exclude        []string   `json:"exclude,omitempty"`
+	MinSize        int64      `json:"min_size,omitempty"`
+	MaxSize        int64      `json:"max_size,omitempty"`
+	Files          []LockFile `json:"files"`
+}
+
+// LockFile records a single downloaded file
+type LockFile struct {
+	Path     string `json:"path"`      // Path relative to the output directory
+	RepoPath string `json:"repo_path"` // Path in the repository
+	Size     int    `json:"size"`
+	Sha      string `json:"sha"`
+}
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MatchFormat reports whether a file name passes the --format filter: it must have
+// one of the formats, if any are given, and none of the excluded ones
+func MatchFormat(name string, formats, excluded []string) bool {
+	for _, ext := range excluded {
+		if hasExtension(name, ext) {
+			return false
+		}
+	}
+	if len(formats) == 0 {
+		return true
+	}
+	for _, ext := range formats {
+		if hasExtension(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExtension reports whether name ends in the extension ext, which may span several
+// dots (tar.gz, d.ts). The empty extension matches names without one, and a name
+// without an extension matches an entry equal to it, so dockerfile matches Dockerfile.
+func hasExtension(name, ext string) bool {
+	if ext == "" {
+		return filepath.Ext(name) == ""
+	}
+	if filepath.Ext(name) == "" && strings.EqualFold(filepath.Base(name), ext) {
+		return true
+	}
+	return strings.HasSuffix(strings.ToLower(name), "."+ext)
+}
+
+// ParseFormats parses a --format expression into the extensions to include and to
+// exclude. Terms are separated by commas and are category names or extensions; a
+// leading - or ! excludes a term (image,document / code,-json / !archive / [jpg,tar.gz]).
+// "" stands for files without an extension.
+func ParseFormats(expression string, formats Formats) ([]string, []string, error) {
+	var include, exclude []string
+	inner := strings.TrimSpace(expression)
+	if strings.HasPrefix(inner, "[") && strings.HasSuffix(inner, "]") {
+		inner = inner[1 : len(inner)-1]
+	}
+	for _, term := range strings.Split(inner, ",") {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term == "" {
+			continue
+		}
+		excluded := strings.HasPrefix(term, "-") || strings.HasPrefix(term, "!")
+		if excluded {
+			term = strings.TrimSpace(term[1:])
+		}
+		if term == "" {
+			return nil, nil, fmt.Errorf("invalid format '%s': nothing to exclude", expression)
+		}
+
+		extensions, isCategory := formats.Categories[term]
+		if !isCategory {
+			if term == `""` {
+				term = ""
+			}
+			extensions = []string{strings.TrimPrefix(term, ".")}
+		}
+		if excluded {
+			exclude = append(exclude, extensions...)
+		} else {
+			include = append(include, extensions...)
+		}
+	}
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil, fmt.Errorf("invalid format '%s'", expression)
+	}
+	return dedupe(include), dedupe(exclude), nil
+}
+
+// dedupe drops repeated strings, keeping the first occurrence
+func dedupe(items []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// FormatConfig is the layout of config/format.json and of the user format config.
+// Extend appends extensions to a category and Composite defines a category as the
+// union of others (e.g. "web": ["code", "image", "fonts"]).
+type FormatConfig struct {
+	Formats   map[string][]string `json:"formats"`
+	Extend    map[string][]string `json:"extend,omitempty"`
+	Composite map[string][]string `json:"composite,omitempty"`
+}
+
+// Formats is the effective set of format categories
+type Formats struct {
+	Categories map[string][]string // Extensions of every category, composites expanded
+	Composites map[string][]string // Member categories of composite categories
+	Source     string              // User config merged over the defaults, if any
+}
+
+// UserFormatConfig returns the default location of the user format config,
+// <config dir>/dgf/format.json (e.g. ~/.config/dgf/format.json)
+func UserFormatConfig() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "dgf", "format.json")
+}
+
+// LoadFormats merges the user format config over the embedded defaults. configPath
+// names the user config explicitly and must exist; otherwise the default location is
+// used when a file is there.
+func LoadFormats(defaults []byte, configPath string) (Formats, error) {
+	formats := Formats{Categories: make(map[string][]string), Composites: make(map[string][]string)}
+
+	var config FormatConfig
+	if err := json.Unmarshal(defaults, &config); err != nil {
+		return formats, fmt.Errorf("failed to parse embedded format config: %v", err)
+	}
+	for name, extensions := range config.Formats {
+		formats.Categories[strings.ToLower(name)] = normalizeExtensions(extensions)
+	}
+
+	if configPath == "" {
+		if _, err := os.Stat(UserFormatConfig()); err != nil {
+			return formats, nil
+		}
+		configPath = UserFormatConfig()
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return formats, fmt.Errorf("failed to read format config: %v", err)
+	}
+	var user FormatConfig
+	if err := json.Unmarshal(data, &user); err != nil {
+		return formats, fmt.Errorf("failed to parse format config %s: %v", configPath, err)
+	}
+	formats.Source = configPath
+
+	// Categories are added or replaced first, then extended
+	for name, extensions := range user.Formats {
+		formats.Categories[strings.ToLower(name)] = normalizeExtensions(extensions)
+	}
+	for name, extensions := range user.Extend {
+		name = strings.ToLower(name)
+		formats.Categories[name] = normalizeExtensions(append(formats.Categories[name], extensions...))
+	}
+
+	// Composites may name plain categories or other composites
+	for name, members := range user.Composite {
+		name = strings.ToLower(name)
+		formats.Composites[name] = make([]string, len(members))
+		for i, member := range members {
+			formats.Composites[name][i] = strings.ToLower(member)
+		}
+	}
+	for name := range formats.Composites {
+		extensions, err := expandComposite(formats, name, map[string]bool{})
+		if err != nil {
+			return formats, fmt.Errorf("format config %s: %v", configPath, err)
+		}
+		formats.Categories[name] = normalizeExtensions(extensions)
+	}
+
+	return formats, nil
+}
+
+// expandComposite returns the extensions of every member of a composite category
+func expandComposite(formats Formats, name string, visiting map[string]bool) ([]string, error) {
+	if visiting[name] {
+		return nil, fmt.Errorf("composite category %s includes itself", name)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	var extensions []string
+	for _, member := range formats.Composites[name] {
+		if _, composite := formats.Composites[member]; composite {
+			memberExtensions, err := expandComposite(formats, member, visiting)
+			if err != nil {
+				return nil, err
+			}
+			extensions = append(extensions, memberExtensions...)
+		} else if memberExtensions, ok := formats.Categories[member]; ok {
+			extensions = append(extensions, memberExtensions...)
+		} else {
+			return nil, fmt.Errorf("composite category %s names unknown category %s", name, member)
+		}
+	}
+	return extensions, nil
+}
+
+// normalizeExtensions lowercases extensions, strips leading dots and drops duplicates
+func normalizeExtensions(extensions []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+		if ext == "" || seen[ext] {
+			continue
+		}
+		seen[ext] = true
+		normalized = append(normalized, ext)
+	}
+	return normalized
+}
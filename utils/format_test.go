@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormats(t *testing.T) {
+	formats := Formats{Categories: map[string][]string{
+		"code":    {"go", "json", "ts"},
+		"image":   {"png", "jpg"},
+		"archive": {"zip", "tar.gz"},
+	}}
+	tests := []struct {
+		expression  string
+		wantInclude []string
+		wantExclude []string
+		wantErr     bool
+	}{
+		{"image", []string{"png", "jpg"}, nil, false},
+		{"image,code", []string{"png", "jpg", "go", "json", "ts"}, nil, false},
+		{"code,-json", []string{"go", "json", "ts"}, []string{"json"}, false},
+		{"!archive", nil, []string{"zip", "tar.gz"}, false},
+		{"-archive", nil, []string{"zip", "tar.gz"}, false},
+		{"[jpg,tar.gz]", []string{"jpg", "tar.gz"}, nil, false},
+		{"[ PDF , .md ]", []string{"pdf", "md"}, nil, false},
+		{"code,-d.ts", []string{"go", "json", "ts"}, []string{"d.ts"}, false},
+		{`""`, []string{""}, nil, false},
+		{"png,image", []string{"png", "jpg"}, nil, false},
+		{"-", nil, nil, true},
+		{"code,!", nil, nil, true},
+		{"", nil, nil, true},
+		{"[]", nil, nil, true},
+		{",,", nil, nil, true},
+	}
+	for _, tt := range tests {
+		include, exclude, err := ParseFormats(tt.expression, formats)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormats(%q): error %v, wantErr %v", tt.expression, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(include, tt.wantInclude) || !reflect.DeepEqual(exclude, tt.wantExclude) {
+			t.Errorf("ParseFormats(%q) = %q, %q, want %q, %q", tt.expression, include, exclude, tt.wantInclude, tt.wantExclude)
+		}
+	}
+}
+
+func TestMatchFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		formats  []string
+		excluded []string
+		want     bool
+	}{
+		{"main.go", nil, nil, true},
+		{"main.go", []string{"go"}, nil, true},
+		{"MAIN.GO", []string{"go"}, nil, true},
+		{"main.go", []string{"js"}, nil, false},
+		{"data.json", []string{"go", "json"}, []string{"json"}, false},
+		{"types.d.ts", []string{"ts"}, []string{"d.ts"}, false},
+		{"index.ts", []string{"ts"}, []string{"d.ts"}, true},
+		{"release.tar.gz", []string{"tar.gz"}, nil, true},
+		{"release.gz", []string{"tar.gz"}, nil, false},
+		{"README", []string{""}, nil, true},
+		{"README.md", []string{""}, nil, false},
+		{"Makefile", nil, []string{""}, false},
+		{"Dockerfile", []string{"dockerfile"}, nil, true},
+		{"app.dockerfile", []string{"dockerfile"}, nil, true},
+		{"Dockerfile.dev", []string{"dockerfile"}, nil, false},
+		{"archive.zip", nil, []string{"zip"}, false},
+	}
+	for _, tt := range tests {
+		if got := MatchFormat(tt.name, tt.formats, tt.excluded); got != tt.want {
+			t.Errorf("MatchFormat(%q, %q, %q) = %v, want %v", tt.name, tt.formats, tt.excluded, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ValidGlob reports an error for an --include or --exclude pattern that cannot match
+func ValidGlob(pattern string) error {
+	if strings.Trim(pattern, "/") == "" {
+		return fmt.Errorf("empty glob pattern '%s'", pattern)
+	}
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// MatchGlob reports whether a repository path matches a gitignore-style pattern.
+// A pattern without a slash matches a file or folder name at any depth, a pattern
+// with one (including a leading slash) is anchored at the repository root, **
+// spans any number of folders and a trailing slash only matches folders. A path
+// also matches when one of its parent folders does, so "node_modules/" covers
+// everything below it.
+func MatchGlob(pattern, filePath string, isDir bool) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	if !anchored {
+		patternSegments = []string{"**", patternSegments[0]}
+	}
+
+	segments := strings.Split(filePath, "/")
+	for i := range segments {
+		// Every prefix but the full path is a parent folder
+		if i == len(segments)-1 && dirOnly && !isDir {
+			break
+		}
+		if matchSegments(patternSegments, segments[:i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where ** matches
+// zero or more segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// MatchGlobs reports whether a file passes the --include and --exclude filters: it
+// must match an include pattern, if any are given, and no exclude pattern
+func MatchGlobs(filePath string, include, exclude []string) bool {
+	if ExcludedPath(filePath, exclude, false) {
+		return false
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if MatchGlob(pattern, filePath, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludedPath reports whether a file or folder matches one of the exclude patterns.
+// Listings use it to skip excluded folders without fetching their contents.
+func ExcludedPath(filePath string, exclude []string, isDir bool) bool {
+	for _, pattern := range exclude {
+		if MatchGlob(pattern, filePath, isDir) {
+			return true
+		}
+	}
+	return false
+}
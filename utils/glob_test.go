@@ -0,0 +1,92 @@
+package utils
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		// Unanchored patterns match a name at any depth
+		{"*.go", "main.go", false, true},
+		{"*.go", "src/lib/util.go", false, true},
+		{"*.go", "src/main.js", false, false},
+		{"README.md", "docs/README.md", false, true},
+		{"test", "src/test/a.go", false, true},
+
+		// Patterns with a slash are anchored at the root
+		{"/build", "build", true, true},
+		{"/build", "build/out.bin", false, true},
+		{"/build", "src/build/out.bin", false, false},
+		{"docs/*.md", "docs/a.md", false, true},
+		{"docs/*.md", "src/docs/a.md", false, false},
+		{"docs/*.md", "docs/img/a.md", false, false},
+
+		// ** spans any number of folders
+		{"**/*.md", "a.md", false, true},
+		{"**/*.md", "docs/deep/a.md", false, true},
+		{"docs/**/*.png", "docs/x.png", false, true},
+		{"docs/**/*.png", "docs/img/deep/x.png", false, true},
+		{"docs/**/*.png", "src/x.png", false, false},
+		{"src/**", "src/lib/util.go", false, true},
+
+		// A trailing slash only matches folders
+		{"node_modules/", "node_modules/pkg/index.js", false, true},
+		{"node_modules/", "web/node_modules/pkg/index.js", false, true},
+		{"node_modules/", "node_modules", true, true},
+		{"node_modules/", "node_modules", false, false},
+		{"build/", "src/build", false, false},
+
+		// Parent folders match too
+		{"vendor", "vendor/github.com/x/y.go", false, true},
+		{"img", "docs/img/x.png", false, true},
+		{"img", "docs/images/x.png", false, false},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path, tt.isDir); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q, %v) = %v, want %v", tt.pattern, tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestMatchGlobs(t *testing.T) {
+	tests := []struct {
+		path    string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{"src/main.go", nil, nil, true},
+		{"src/main.go", []string{"*.go"}, nil, true},
+		{"src/main.go", []string{"*.md"}, nil, false},
+		{"src/main.go", []string{"*.md", "src/"}, nil, true},
+		{"src/main_test.go", []string{"*.go"}, []string{"*_test.go"}, false},
+		{"vendor/x.go", nil, []string{"vendor/"}, false},
+	}
+	for _, tt := range tests {
+		if got := MatchGlobs(tt.path, tt.include, tt.exclude); got != tt.want {
+			t.Errorf("MatchGlobs(%q, %q, %q) = %v, want %v", tt.path, tt.include, tt.exclude, got, tt.want)
+		}
+	}
+}
+
+func TestValidGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{"*.go", false},
+		{"docs/**/*.md", false},
+		{"/build/", false},
+		{"", true},
+		{"/", true},
+		{"[a-", true},
+	}
+	for _, tt := range tests {
+		if err := ValidGlob(tt.pattern); (err != nil) != tt.wantErr {
+			t.Errorf("ValidGlob(%q): error %v, wantErr %v", tt.pattern, err, tt.wantErr)
+		}
+	}
+}
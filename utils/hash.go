@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// GitBlobHash computes the git object ID of a file: the hash of "blob <len>\0" followed
+// by its content. SHA-256 is used for repositories in the sha256 object format.
+func GitBlobHash(path string, useSHA256 bool) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	var h hash.Hash
+	if useSHA256 {
+		h = sha256.New()
+	} else {
+		h = sha1.New()
+	}
+	fmt.Fprintf(h, "blob %d\x00", info.Size())
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ContentHash computes the plain SHA-256 of a file's content
+func ContentHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileHash hashes a file the same way as the listed SHA it is compared with.
+// A "sha256:" prefix denotes a plain content digest (LFS objects, release assets);
+// otherwise the length selects a SHA-1 (40) or SHA-256 (64) git blob hash.
+func FileHash(path, expected string) (string, error) {
+	if strings.HasPrefix(expected, "sha256:") {
+		digest, err := ContentHash(path)
+		return "sha256:" + digest, err
+	}
+	switch len(expected) {
+	case 40:
+		return GitBlobHash(path, false)
+	case 64:
+		return GitBlobHash(path, true)
+	}
+	return "", fmt.Errorf("unsupported SHA format %q", expected)
+}
+
+// IsPotentialCommitHash checks if a string could be a Git commit hash: hex digits, at
+// most 40 characters (SHA-1) and at least minLength, 7 for the usual short hashes
+func IsPotentialCommitHash(s string, minLength int) bool {
+	if len(s) < minLength || len(s) > 40 {
+		return false
+	}
+	for _, c := range s {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
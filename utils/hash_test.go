@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTemp writes content to a file in a temporary directory and returns its path
+func writeTemp(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGitBlobHash(t *testing.T) {
+	tests := []struct {
+		content   string
+		useSHA256 bool
+		want      string
+	}{
+		{"", false, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+		{"hello\n", false, "ce013625030ba8dba906f756967f9e9ca394464a"},
+		{"", true, "473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813"},
+		{"hello\n", true, "2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4"},
+	}
+	for _, tt := range tests {
+		got, err := GitBlobHash(writeTemp(t, tt.content), tt.useSHA256)
+		if err != nil {
+			t.Fatalf("GitBlobHash(%q, %v): %v", tt.content, tt.useSHA256, err)
+		}
+		if got != tt.want {
+			t.Errorf("GitBlobHash(%q, %v) = %s, want %s", tt.content, tt.useSHA256, got, tt.want)
+		}
+	}
+}
+
+func TestGitBlobHashMissingFile(t *testing.T) {
+	if _, err := GitBlobHash(filepath.Join(t.TempDir(), "missing"), false); !os.IsNotExist(err) {
+		t.Errorf("GitBlobHash of a missing file: got %v, want a not-exist error", err)
+	}
+}
+
+func TestFileHash(t *testing.T) {
+	path := writeTemp(t, "hello\n")
+	tests := []struct {
+		expected string
+		want     string
+		wantErr  bool
+	}{
+		{"ce013625030ba8dba906f756967f9e9ca394464a", "ce013625030ba8dba906f756967f9e9ca394464a", false},
+		{"2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4", "2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4", false},
+		{"sha256:0000", "sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03", false},
+		{"abc123", "", true},
+	}
+	for _, tt := range tests {
+		got, err := FileHash(path, tt.expected)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("FileHash(%q): error %v, wantErr %v", tt.expected, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("FileHash(%q) = %s, want %s", tt.expected, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+)
+
+// Client is the HTTP client shared by every request so connections are kept alive
+// and reused across API calls and parallel downloads
+var Client = &http.Client{Transport: Transport}
+
+// newTransport returns a transport tuned for many concurrent requests to few hosts
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 32
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ResponseHeaderTimeout = 60 * time.Second
+	return transport
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseLineRange parses a line range given as an anchor (L10, L10-L42) or a flag
+// value (10, 10-42). A single line yields equal start and end.
+func ParseLineRange(s string) (int, int, bool) {
+	first, last, isRange := strings.Cut(s, "-")
+	start, err := strconv.Atoi(strings.TrimPrefix(first, "L"))
+	if err != nil || start < 1 {
+		return 0, 0, false
+	}
+	if !isRange {
+		return start, start, true
+	}
+	end, err := strconv.Atoi(strings.TrimPrefix(last, "L"))
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestParseLineRange(t *testing.T) {
+	tests := []struct {
+		s          string
+		start, end int
+		ok         bool
+	}{
+		{"L10", 10, 10, true},
+		{"L10-L42", 10, 42, true},
+		{"10", 10, 10, true},
+		{"10-42", 10, 42, true},
+		{"L7-7", 7, 7, true},
+		{"L1", 1, 1, true},
+		{"L0", 0, 0, false},
+		{"0-5", 0, 0, false},
+		{"-1", 0, 0, false},
+		{"L42-L10", 0, 0, false},
+		{"10-", 0, 0, false},
+		{"L10-Lx", 0, 0, false},
+		{"readme", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, end, ok := ParseLineRange(tt.s)
+		if start != tt.start || end != tt.end || ok != tt.ok {
+			t.Errorf("ParseLineRange(%q) = %d, %d, %v, want %d, %d, %v", tt.s, start, end, ok, tt.start, tt.end, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version parsed from a tag such as v1.4.2 or 2.0.0-rc.1
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string
+}
+
+// ParseVersion parses a tag as a semantic version. A leading "v" is allowed, missing
+// minor and patch numbers default to 0 and build metadata is ignored.
+func ParseVersion(tag string) (Version, bool) {
+	var v Version
+	s := strings.TrimPrefix(strings.TrimPrefix(tag, "v"), "V")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Pre = s[i+1:]
+		s = s[:i]
+		if v.Pre == "" {
+			return v, false
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		*numbers[i] = n
+	}
+	return v, true
+}
+
+// String formats the version without a leading "v"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or higher than o.
+// Pre-releases sort below the release they precede.
+func (v Version) Compare(o Version) int {
+	if c := compareInt(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+
+	// Compare dot-separated pre-release identifiers, numeric ones numerically
+	a, b := strings.Split(v.Pre, "."), strings.Split(o.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		switch {
+		case errX == nil && errY == nil:
+			if c := compareInt(x, y); c != 0 {
+				return c
+			}
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// compareInt returns -1, 0 or 1 when a is lower than, equal to or higher than b
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// comparator is a single bound of a version range
+type comparator struct {
+	op      string // =, <, <=, > or >=
+	version Version
+}
+
+// VersionConstraint selects versions by a constraint such as ^1.4, ~1.4.2, 1.x,
+// ">=1.2 <2", "^1 || ^2" or latest
+type VersionConstraint struct {
+	ranges     [][]comparator // Alternatives, each a list of bounds that must all hold
+	prerelease bool           // Whether the constraint names a pre-release
+}
+
+// ParseConstraint parses a version constraint. Terms separated by spaces or commas must
+// all match, and alternatives are separated by "||". "latest" and "*" match any version.
+func ParseConstraint(constraint string) (VersionConstraint, error) {
+	var c VersionConstraint
+	for _, alternative := range strings.Split(constraint, "||") {
+		terms := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(terms) == 0 {
+			return c, fmt.Errorf("invalid version constraint '%s'", constraint)
+		}
+		var bounds []comparator
+		for _, term := range terms {
+			termBounds, err := parseTerm(term)
+			if err != nil {
+				return c, fmt.Errorf("invalid version constraint '%s': %v", constraint, err)
+			}
+			for _, bound := range termBounds {
+				if bound.version.Pre != "" {
+					c.prerelease = true
+				}
+			}
+			bounds = append(bounds, termBounds...)
+		}
+		c.ranges = append(c.ranges, bounds)
+	}
+	return c, nil
+}
+
+// parseTerm turns one term of a constraint into the bounds it implies
+func parseTerm(term string) ([]comparator, error) {
+	if term == "latest" || term == "*" || term == "x" || term == "X" {
+		return nil, nil
+	}
+
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+
+	// Count the numbers given before any x or * wildcard
+	s := strings.TrimPrefix(term, op)
+	parts := strings.Split(strings.SplitN(strings.TrimPrefix(s, "v"), "-", 2)[0], ".")
+	given := 0
+	for _, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		given++
+	}
+	version, ok := ParseVersion(strings.Join(parts[:given], "."))
+	if given > 0 && given < len(parts) {
+		// A wildcard cannot be followed by a pre-release
+		version.Pre = ""
+	} else if given == len(parts) {
+		version, ok = ParseVersion(s)
+	}
+	if given == 0 {
+		if op == "" || op == "=" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("'%s' has no version", term)
+	}
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a version", term)
+	}
+
+	// Versions below the first one that no longer matches the given numbers
+	upper := Version{Major: version.Major + 1}
+	if given == 2 {
+		upper = Version{Major: version.Major, Minor: version.Minor + 1}
+	}
+
+	switch op {
+	case "^":
+		// Allow changes that do not modify the leftmost non-zero number
+		switch {
+		case version.Major > 0 || given == 1:
+			upper = Version{Major: version.Major + 1}
+		case version.Minor > 0 || given == 2:
+			upper = Version{Minor: version.Minor + 1}
+		default:
+			upper = Version{Patch: version.Patch + 1}
+		}
+		return []comparator{{">=", version}, {"<", upper}}, nil
+	case "~":
+		if given > 1 {
+			upper = Version{Major: version.Major, Minor: version.Minor + 1}
+		}
+		return []comparator{{">=", version}, {"<", upper}}, nil
+	case ">":
+		if given < 3 {
+			return []comparator{{">=", upper}}, nil
+		}
+	case "<=":
+		if given < 3 {
+			return []comparator{{"<", upper}}, nil
+		}
+	case "", "=":
+		if given < 3 {
+			return []comparator{{">=", version}, {"<", upper}}, nil
+		}
+		op = "="
+	}
+	return []comparator{{op, version}}, nil
+}
+
+// Match reports whether v satisfies the constraint. Pre-releases only match when the
+// constraint itself names one.
+func (c VersionConstraint) Match(v Version) bool {
+	if v.Pre != "" && !c.prerelease {
+		return false
+	}
+	for _, bounds := range c.ranges {
+		matched := true
+		for _, bound := range bounds {
+			if !bound.match(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether v satisfies a single bound
+func (b comparator) match(v Version) bool {
+	c := v.Compare(b.version)
+	switch b.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return c == 0
+}
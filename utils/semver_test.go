@@ -0,0 +1,153 @@
+package utils
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"v1.4.2", "1.4.2", true},
+		{"V3.1", "3.1.0", true},
+		{"2", "2.0.0", true},
+		{"1.0.0-rc.1", "1.0.0-rc.1", true},
+		{"1.0.0-rc.1+build.5", "1.0.0-rc.1", true},
+		{"1.2.3+build", "1.2.3", true},
+		{"1.2.3.4", "", false},
+		{"1.0.0-", "", false},
+		{"1.-2.0", "", false},
+		{"release", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		v, ok := ParseVersion(tt.tag)
+		if ok != tt.ok {
+			t.Errorf("ParseVersion(%q) ok = %v, want %v", tt.tag, ok, tt.ok)
+			continue
+		}
+		if ok && v.String() != tt.want {
+			t.Errorf("ParseVersion(%q) = %s, want %s", tt.tag, v, tt.want)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.0.0", "1.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2.3", "1.2.4", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	}
+	for _, tt := range tests {
+		a, _ := ParseVersion(tt.a)
+		b, _ := ParseVersion(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.Compare(a); got != -tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestConstraintMatch(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// Caret ranges keep the leftmost non-zero number
+		{"^1.4", "1.4.0", true},
+		{"^1.4", "1.9.9", true},
+		{"^1.4", "1.3.9", false},
+		{"^1.4", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.x", "0.9.0", true},
+		{"^0.x", "1.0.0", false},
+		{"^0", "0.5.1", true},
+
+		// Tilde ranges allow patch changes, or minor changes with only a major
+		{"~1.4.2", "1.4.9", true},
+		{"~1.4.2", "1.4.1", false},
+		{"~1.4.2", "1.5.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+
+		// x-ranges and partial versions
+		{"1.x", "1.0.0", true},
+		{"1.x", "1.99.0", true},
+		{"1.x", "2.0.0", false},
+		{"1.4.x", "1.4.7", true},
+		{"1.4.x", "1.5.0", false},
+		{"1.4", "1.4.3", true},
+		{"1.4", "1.5.0", false},
+		{"*", "3.2.1", true},
+
+		// Comparison operators on partial versions
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{">1.2.3", "1.2.3", false},
+		{">1.2.3", "1.2.4", true},
+		{"<2", "1.9.9", true},
+		{"<2", "2.0.0", false},
+
+		// Exact versions
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"v1.2.3", "1.2.3", true},
+
+		// Intersections and alternatives
+		{">=1.2 <2", "1.2.0", true},
+		{">=1.2 <2", "1.1.9", false},
+		{">=1.2, <2", "2.0.0", false},
+		{"^1 || ^3", "1.5.0", true},
+		{"^1 || ^3", "2.0.0", false},
+		{"^1 || ^3", "3.1.0", true},
+
+		// Pre-releases only match constraints that name one
+		{"latest", "5.0.0", true},
+		{"latest", "5.0.0-rc.1", false},
+		{"^1.4", "1.5.0-beta", false},
+		{"^2.0.0-rc.1", "2.0.0-rc.2", true},
+		{"^2.0.0-rc.1", "2.0.0-rc.0", false},
+		{"^2.0.0-rc.1", "2.0.0", true},
+		{"^2.0.0-rc.1", "1.9.0", false},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		v, ok := ParseVersion(tt.version)
+		if !ok {
+			t.Fatalf("ParseVersion(%q) failed", tt.version)
+		}
+		if got := c.Match(v); got != tt.want {
+			t.Errorf("%q matches %s = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, constraint := range []string{"", "^1 ||", ">=", ">x", "^abc", "~1.2.3.4", "1.0.0-"} {
+		if _, err := ParseConstraint(constraint); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want an error", constraint)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
 // FormatSize takes a list of file sizes in bytes and returns a formatted string
@@ -31,4 +34,22 @@ func FormatSize(fileSizes []int) string {
 	}
 	// Other units: display with up to 2 decimal places
 	return fmt.Sprintf("%.2f %s", size, units[unitIndex])
-}
\ No newline at end of file
+}
+
+// ParseSize parses a size such as "500", "10KB", "1.5M" or "40GiB" into bytes. Units
+// are binary like FormatSize, so "1KB" is 1024 bytes.
+func ParseSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	number := strings.TrimRightFunc(value, unicode.IsLetter)
+	unit := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(value[len(number):]), "b"), "i")
+	multipliers := map[string]float64{"": 1, "k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40, "p": 1 << 50}
+	multiplier, ok := multipliers[unit]
+	number = strings.TrimSpace(number)
+	// Only plain decimals are sizes, not exponents, signs or hex floats
+	plain := strings.Trim(number, "0123456789.") == "" && strings.Count(number, ".") <= 1
+	n, err := strconv.ParseFloat(number, 64)
+	if !ok || !plain || err != nil {
+		return 0, fmt.Errorf("invalid size '%s' (expected e.g. 500, 10KB, 1.5MB or 40GB)", s)
+	}
+	return int64(n * multiplier), nil
+}
@@ -0,0 +1,63 @@
+package utils
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    int64
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"500", 500, false},
+		{" 500 ", 500, false},
+		{"10KB", 10 << 10, false},
+		{"10kb", 10 << 10, false},
+		{"10K", 10 << 10, false},
+		{"10 KB", 10 << 10, false},
+		{"1.5M", 3 << 19, false},
+		{"1.5MB", 3 << 19, false},
+		{"40GiB", 40 << 30, false},
+		{"2TB", 2 << 40, false},
+		{"1PB", 1 << 50, false},
+		{"100B", 100, false},
+		{".5K", 512, false},
+		{"-1", 0, true},
+		{"-1KB", 0, true},
+		{"1e3", 0, true},
+		{"1E3KB", 0, true},
+		{"0x10", 0, true},
+		{"1.2.3", 0, true},
+		{"10XB", 0, true},
+		{"KB", 0, true},
+		{"inf", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q): error %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		sizes []int
+		want  string
+	}{
+		{nil, "0 bytes"},
+		{[]int{1023}, "1023 bytes"},
+		{[]int{512, 512}, "1.00 Kb"},
+		{[]int{3 << 19}, "1.50 Mb"},
+	}
+	for _, tt := range tests {
+		if got := FormatSize(tt.sizes); got != tt.want {
+			t.Errorf("FormatSize(%v) = %q, want %q", tt.sizes, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SniffLength is the number of leading bytes DetectExtension looks at
+const SniffLength = 512
+
+// magicNumbers maps file signatures that http.DetectContentType does not know to
+// the extension of their type
+var magicNumbers = []struct {
+	offset    int
+	signature string
+	ext       string
+}{
+	{0, "7z\xbc\xaf\x27\x1c", "7z"},
+	{0, "\xfd7zXZ\x00", "xz"},
+	{0, "BZh", "bz2"},
+	{0, "LZIP", "lz"},
+	{0, "II*\x00", "tiff"},
+	{0, "MM\x00*", "tiff"},
+	{0, "8BPS", "psd"},
+	{0, "fLaC", "flac"},
+	{0, "#!AMR", "amr"},
+	{0, "SQLite format 3\x00", "sqlite"},
+	{0, "\x7fELF", "bin"},
+	{0, "MZ", "exe"},
+	{0, "OTTO", "otf"},
+	{0, "\x00\x01\x00\x00\x00", "ttf"},
+	{0, "glTF", "glb"},
+	{4, "ftypheic", "heic"},
+	{4, "ftypheix", "heic"},
+	{4, "ftypM4V", "m4v"},
+	{4, "ftypM4A", "m4a"},
+	{4, "ftypqt", "mov"},
+	{4, "ftyp3gp", "3gp"},
+	{257, "ustar", "tar"},
+}
+
+// mimeExtensions maps MIME types reported by http.DetectContentType to an extension
+var mimeExtensions = map[string]string{
+	"image/png":                     "png",
+	"image/jpeg":                    "jpg",
+	"image/gif":                     "gif",
+	"image/webp":                    "webp",
+	"image/bmp":                     "bmp",
+	"image/x-icon":                  "ico",
+	"application/pdf":               "pdf",
+	"application/postscript":        "eps",
+	"application/zip":               "zip",
+	"application/x-gzip":            "gz",
+	"application/x-rar-compressed":  "rar",
+	"application/vnd.ms-fontobject": "eot",
+	"font/ttf":                      "ttf",
+	"font/otf":                      "otf",
+	"font/woff":                     "woff",
+	"font/woff2":                    "woff2",
+	"video/mp4":                     "mp4",
+	"video/webm":                    "webm",
+	"video/avi":                     "avi",
+	"audio/mpeg":                    "mp3",
+	"audio/wave":                    "wav",
+	"audio/aiff":                    "aiff",
+	"application/ogg":               "ogg",
+	"text/html":                     "html",
+	"text/xml":                      "xml",
+}
+
+// interpreters maps the program of a #! line to the extension of its scripts
+var interpreters = map[string]string{
+	"sh": "sh", "bash": "sh", "zsh": "sh", "dash": "sh", "ksh": "sh",
+	"python": "py", "python2": "py", "python3": "py",
+	"node": "js", "ruby": "rb", "perl": "pl", "php": "php",
+}
+
+// DetectExtension classifies the leading bytes of a file by magic number, MIME type,
+// #! line or Dockerfile syntax and returns the extension of the detected type, or ""
+// when the content is not recognised
+func DetectExtension(head []byte) string {
+	for _, magic := range magicNumbers {
+		if len(head) >= magic.offset+len(magic.signature) && string(head[magic.offset:magic.offset+len(magic.signature)]) == magic.signature {
+			return magic.ext
+		}
+	}
+
+	// Scripts name their interpreter, directly or through env
+	if bytes.HasPrefix(head, []byte("#!")) {
+		line, _, _ := strings.Cut(string(head[2:]), "\n")
+		fields := strings.Fields(line)
+		if len(fields) > 1 && path.Base(fields[0]) == "env" {
+			fields = fields[1:]
+		}
+		if len(fields) > 0 {
+			if ext, ok := interpreters[path.Base(fields[0])]; ok {
+				return ext
+			}
+		}
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if ext, ok := mimeExtensions[mimeType]; ok {
+		if ext == "xml" && bytes.Contains(head, []byte("<svg")) {
+			return "svg"
+		}
+		return ext
+	}
+	if strings.HasPrefix(mimeType, "text/") {
+		if bytes.HasPrefix(bytes.TrimSpace(head), []byte("<svg")) {
+			return "svg"
+		}
+		if isDockerfile(head) {
+			return "dockerfile"
+		}
+	}
+	return ""
+}
+
+// isDockerfile reports whether the first instruction of a text file is FROM or ARG,
+// after comments and blank lines
+func isDockerfile(head []byte) bool {
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		instruction := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		return (instruction == "FROM" || instruction == "ARG") && strings.Contains(line, " ")
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectExtension(t *testing.T) {
+	tar := make([]byte, 300)
+	copy(tar[257:], "ustar")
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		// Magic numbers
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), "jpg"},
+		{"pdf", []byte("%PDF-1.7\n"), "pdf"},
+		{"zip", []byte("PK\x03\x04\x14\x00"), "zip"},
+		{"gzip", []byte("\x1f\x8b\x08\x00"), "gz"},
+		{"7z", []byte("7z\xbc\xaf\x27\x1c\x00\x04"), "7z"},
+		{"elf", []byte("\x7fELF\x02\x01\x01"), "bin"},
+		{"sqlite", []byte("SQLite format 3\x00"), "sqlite"},
+		{"heic at offset", []byte("\x00\x00\x00\x18ftypheic"), "heic"},
+		{"tar at offset", tar, "tar"},
+		{"svg", []byte("<?xml version=\"1.0\"?>\n<svg xmlns=\"http://www.w3.org/2000/svg\">"), "svg"},
+		{"bare svg", []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), "svg"},
+
+		// Scripts
+		{"sh", []byte("#!/bin/sh\necho hi\n"), "sh"},
+		{"env python", []byte("#!/usr/bin/env python3\nprint(1)\n"), "py"},
+		{"node", []byte("#!/usr/local/bin/node\n"), "js"},
+		{"unknown interpreter", []byte("#!/usr/bin/tclsh\n"), ""},
+
+		// Dockerfiles
+		{"dockerfile", []byte("# build image\n\nFROM golang:1.21\nRUN go build\n"), "dockerfile"},
+		{"dockerfile arg", []byte("ARG VERSION=1\nFROM alpine:$VERSION\n"), "dockerfile"},
+		{"bare from", []byte("FROM\n"), ""},
+		{"plain text", []byte("hello world\n"), ""},
+
+		// Short buffers
+		{"empty", nil, ""},
+		{"one byte", []byte("M"), ""},
+		{"short magic", []byte("MZ"), "exe"},
+		{"truncated png", []byte("\x89PN"), ""},
+		{"short before offset", []byte("\x00\x00\x00\x18ftyp"), ""},
+	}
+	for _, tt := range tests {
+		if got := DetectExtension(tt.head); got != tt.want {
+			t.Errorf("DetectExtension(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectExtensionLongText(t *testing.T) {
+	head := []byte(strings.Repeat("plain text line\n", SniffLength/16))
+	if got := DetectExtension(head); got != "" {
+		t.Errorf("DetectExtension(long text) = %q, want \"\"", got)
+	}
+}
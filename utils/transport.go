@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned when the API quota is exhausted and resets later than the transport may wait
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "API rate limit exceeded; provide a token with --token to raise the limit"
+	}
+	return fmt.Sprintf("API rate limit exceeded; quota resets at %s (in %s); provide a token with --token to raise the limit",
+		e.Reset.Local().Format("15:04:05"), time.Until(e.Reset).Round(time.Second))
+}
+
+// RetryTransport retries transient failures with exponential backoff and jitter,
+// and waits for (or fails fast on) exhausted rate limits
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int           // Retries for network errors, 5xx responses and rate limits
+	MaxWait    time.Duration // Longest wait for a rate limit reset before failing fast
+	Log        io.Writer     // Receives notices about waits and retries
+}
+
+// Transport is the retrying transport used by Client
+var Transport = &RetryTransport{
+	Base:       newTransport(),
+	MaxRetries: 4,
+	MaxWait:    time.Minute,
+	Log:        os.Stderr,
+}
+
+// RoundTrip sends the request, retrying it when the failure is transient. Every
+// retry sends a clone, since a RoundTripper must not modify the caller's request.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			// Requests with a body can only be resent if it can be recreated
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					return nil, fmt.Errorf("cannot retry request with body")
+				}
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.Base.RoundTrip(attemptReq)
+		if err != nil {
+			if req.Context().Err() != nil || attempt >= t.MaxRetries {
+				return nil, err
+			}
+			t.sleep(req, backoff(attempt), "network error: %v", err)
+			continue
+		}
+
+		// Wait for the quota to reset or fail fast with the reset time
+		if wait, limited := rateLimitWait(resp); limited {
+			if wait > t.MaxWait || attempt >= t.MaxRetries {
+				resp.Body.Close()
+				return nil, &RateLimitError{Reset: time.Now().Add(wait).Truncate(time.Second)}
+			}
+			drain(resp)
+			t.sleep(req, wait, "rate limit reached for %s", req.URL.Host)
+			continue
+		}
+
+		// Retry transient server errors
+		if isTransient(resp.StatusCode) && attempt < t.MaxRetries {
+			drain(resp)
+			t.sleep(req, backoff(attempt), "%s returned %d", req.URL.Host, resp.StatusCode)
+			continue
+		}
+
+		return resp, nil
+	}
+}
+
+// sleep logs the reason for a retry and waits, returning early if the request is canceled
+func (t *RetryTransport) sleep(req *http.Request, wait time.Duration, format string, args ...interface{}) {
+	if t.Log != nil {
+		fmt.Fprintf(t.Log, "%s, retrying in %s\n", fmt.Sprintf(format, args...), wait.Round(time.Millisecond))
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-req.Context().Done():
+	}
+}
+
+// rateLimitWait reports whether a response signals an exhausted rate limit and how long to wait.
+// It understands Retry-After as well as the X-RateLimit-* (GitHub) and RateLimit-* (GitLab) headers.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return time.Until(date), true
+		}
+	}
+
+	remaining := firstHeader(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if resp.StatusCode == http.StatusForbidden && remaining != "0" {
+		// A plain 403 is a permission error, not a rate limit
+		return 0, false
+	}
+	if reset, err := strconv.ParseInt(firstHeader(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+		wait := time.Until(time.Unix(reset, 0))
+		if wait < 0 {
+			wait = 0
+		}
+		return wait + time.Second, true
+	}
+
+	return backoff(0), true
+}
+
+// firstHeader returns the first non-empty value among the given header keys
+func firstHeader(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// isTransient reports whether a status code is worth retrying
+func isTransient(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the exponential delay for an attempt with jitter in [d/2, d)
+func backoff(attempt int) time.Duration {
+	d := 500 * time.Millisecond << attempt
+	if d > 30*time.Second {
+		d = 30 * time.Second
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
+
+// drain discards and closes a response body so the connection can be reused
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
@@ -27,7 +27,7 @@ func TreePrint(structure types.RepositoryStructure) {
 	}
 
 	// Combine files and folders
-	structurePaths := append(structure.Files, structure.Folders...)
+	structurePaths := append(append([]string{}, structure.Files...), structure.Folders...)
 	sort.Strings(structurePaths)
 
 	// Build tree structure